@@ -0,0 +1,26 @@
+# Data scientists can run executions in development but not in production
+data "unionai_authorization_check" "ml_dev" {
+  user    = unionai_user.nelson.id
+  action  = "create_flyte_executions"
+  project = "ml"
+  domain  = "development"
+}
+
+data "unionai_authorization_check" "ml_prod" {
+  user    = unionai_user.nelson.id
+  action  = "create_flyte_executions"
+  project = "ml"
+  domain  = "production"
+}
+
+check "data_scientist_permissions" {
+  assert {
+    condition     = data.unionai_authorization_check.ml_dev.allowed
+    error_message = "Data scientists must be able to run executions in ml/development."
+  }
+
+  assert {
+    condition     = !data.unionai_authorization_check.ml_prod.allowed
+    error_message = "Data scientists must not be able to run executions in ml/production."
+  }
+}
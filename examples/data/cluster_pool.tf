@@ -0,0 +1,7 @@
+data "unionai_cluster_pool" "gpu" {
+  name = "gpu-pool"
+}
+
+output "gpu_pool_dataplanes" {
+  value = data.unionai_cluster_pool.gpu.dataplanes
+}
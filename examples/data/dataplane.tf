@@ -5,3 +5,23 @@ data "unionai_dataplane" "example" {
 output "dataplane" {
   value = data.unionai_dataplane.example
 }
+
+# Wait for a dataplane bootstrapped in the same run before deploying to it
+data "unionai_dataplane" "new" {
+  id               = "union-eu-west-1"
+  wait_for_healthy = true
+  timeout          = "30m"
+}
+
+# Fail the plan early when the dataplane cannot take GPU workloads
+check "dataplane_ready" {
+  assert {
+    condition     = data.unionai_dataplane.example.health == "HEALTHY"
+    error_message = "Dataplane is unhealthy: ${join("; ", data.unionai_dataplane.example.unhealthy_reasons)}"
+  }
+
+  assert {
+    condition     = try(data.unionai_dataplane.example.capabilities.capacity.gpu_unscaled, 0) >= 8
+    error_message = "Dataplane has fewer than 8 GPUs."
+  }
+}
@@ -0,0 +1,7 @@
+data "unionai_dataplane_pools" "production" {
+  dataplane = "my-dataplane"
+}
+
+output "production_pools" {
+  value = data.unionai_dataplane_pools.production.pools
+}
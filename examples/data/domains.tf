@@ -0,0 +1,12 @@
+data "unionai_domains" "all" {}
+
+// Grant a role on every domain of the project, including custom ones
+resource "unionai_policy" "all_domains" {
+  name = "all-domains"
+
+  project {
+    id      = unionai_project.test.id
+    role_id = unionai_role.example.id
+    domains = data.unionai_domains.all.ids
+  }
+}
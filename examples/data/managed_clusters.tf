@@ -0,0 +1,16 @@
+data "unionai_managed_clusters" "all" {}
+
+# Instance types offered by each node group, with their displayed GPU count
+output "gpu_node_groups" {
+  value = {
+    for pair in flatten([
+      for c in data.unionai_managed_clusters.all.clusters : [
+        for g in c.node_groups : {
+          key            = "${c.name}/${g.name}"
+          instance_types = g.instance_types
+          gpus           = [for r in g.display_resources : r.value if r.name == "gpu"]
+        }
+      ]
+    ]) : pair.key => pair if length(pair.gpus) > 0
+  }
+}
@@ -0,0 +1,11 @@
+data "unionai_nodepools" "dataplane" {
+  dataplane = "my-dataplane"
+}
+
+# Observed GPU capacity per nodepool
+output "gpu_capacity" {
+  value = {
+    for np in data.unionai_nodepools.dataplane.nodepools :
+    np.name => try(np.status.observed["gpu"], "0")
+  }
+}
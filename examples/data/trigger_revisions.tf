@@ -0,0 +1,20 @@
+data "unionai_trigger_revisions" "nightly" {
+  project   = "my-project"
+  domain    = "production"
+  task_name = "training_env.train"
+  name      = "nightly"
+  limit     = 10
+}
+
+# Who changed the schedule, and when
+output "nightly_changes" {
+  value = [
+    for r in data.unionai_trigger_revisions.nightly.revisions : {
+      revision = r.revision
+      action   = r.action
+      at       = r.created_at
+      by       = r.actor.name
+      cron     = r.spec.cron
+    }
+  ]
+}
@@ -0,0 +1,7 @@
+# Adopt a dataplane that registered when the Union operator was installed
+resource "unionai_dataplane" "production" {
+  name = "union-us-east-2"
+
+  # Allow destroying the resource to deregister the dataplane
+  deletion_protection = false
+}
@@ -0,0 +1,34 @@
+resource "unionai_managed_cluster" "prod" {
+  name  = "prod-aws"
+  cloud = "aws"
+
+  node_group {
+    name           = "cpu"
+    min_nodes      = 1
+    max_nodes      = 10
+    disk_size_gbs  = 200
+    instance_types = ["m6i.4xlarge", "m5.4xlarge"]
+  }
+
+  # Spot GPU nodes that only accept workloads tolerating the GPU taint
+  node_group {
+    name           = "gpu"
+    min_nodes      = 0
+    max_nodes      = 4
+    disk_size_gbs  = 500
+    instance_types = ["g5.12xlarge"]
+    spot_instances = true
+    labels = {
+      "union.ai/accelerator" = "a10g"
+    }
+
+    taint {
+      key    = "nvidia.com/gpu"
+      effect = "no_schedule"
+    }
+  }
+
+  timeouts {
+    create = "90m"
+  }
+}
@@ -0,0 +1,34 @@
+# GPU nodes that only accept workloads tolerating the GPU taint
+resource "unionai_nodepool" "gpu" {
+  dataplane = "my-dataplane"
+  name      = "gpu-a100"
+
+  direct {
+    min_nodes = 0
+    max_nodes = 8
+    labels = {
+      "node.kubernetes.io/instance-type" = "p4d.24xlarge"
+    }
+    allocatable = {
+      cpu    = "94"
+      memory = "1100Gi"
+      gpu    = "8"
+    }
+
+    taint {
+      key    = "nvidia.com/gpu"
+      effect = "no_schedule"
+    }
+  }
+}
+
+# Nodepool backed by an existing EKS managed node group
+resource "unionai_nodepool" "cpu" {
+  dataplane = "my-dataplane"
+  name      = "cpu-general"
+
+  reference {
+    provider = "aws"
+    id       = "eks-cpu-general-20240101"
+  }
+}
@@ -0,0 +1,4 @@
+# Every new user of the organization gets the viewer policy
+resource "unionai_org_settings" "this" {
+  default_policy = unionai_policy.viewer.id
+}
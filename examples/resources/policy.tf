@@ -25,6 +25,20 @@ resource "unionai_policy" "some_service" {
       "development",
     ]
   }
+
+  // A single launch plan
+  launch_plan {
+    id      = "nightly_training"
+    project = unionai_project.test.id
+    domain  = "production"
+    role_id = unionai_role.example.id
+  }
+
+  // A single cluster
+  cluster {
+    id      = "gpu-cluster"
+    role_id = unionai_role.example.id
+  }
 }
 
 output "policy_some_service" {
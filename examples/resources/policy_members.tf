@@ -0,0 +1,13 @@
+# Only these principals hold the admin policy, assignments made in the UI are removed
+resource "unionai_policy_members" "admins" {
+  policy       = unionai_policy.some_service.id
+  users        = [unionai_user.nelson.id]
+  applications = [unionai_application.myapp.id]
+}
+
+# Add principals to the viewer policy, leaving other viewers assigned
+resource "unionai_policy_members" "viewers" {
+  policy    = data.unionai_policy.viewer.id
+  users     = ["user-subject-1", "user-subject-2"]
+  exclusive = false
+}
@@ -0,0 +1,37 @@
+# Organization-wide secret. The value is write-only: it is never stored in
+# the Terraform plan or state, so bump value_version to push a new value.
+resource "unionai_secret" "org" {
+  name          = "slack-webhook"
+  value         = var.slack_webhook
+  value_version = 1
+}
+
+# Secret visible only to the development domain of one project
+resource "unionai_secret" "project" {
+  name          = "db-password"
+  project       = unionai_project.test.id
+  domain        = "development"
+  value         = var.db_password
+  value_version = 3
+}
+
+# Binary secret, passed base64-encoded
+resource "unionai_secret" "keystore" {
+  name          = "keystore"
+  binary_value  = filebase64("${path.module}/keystore.jks")
+  value_version = 1
+}
+
+# Image pull secret holding a docker config JSON
+resource "unionai_secret" "registry" {
+  name = "registry-credentials"
+  type = "SECRET_TYPE_IMAGE_PULL_SECRET"
+  value = jsonencode({
+    auths = {
+      "ghcr.io" = {
+        auth = base64encode("user:${var.registry_token}")
+      }
+    }
+  })
+  value_version = 1
+}
@@ -0,0 +1,87 @@
+# Model server that scales to zero and reads its token from a secret
+resource "unionai_serving_app" "model_server" {
+  name    = "model-server"
+  project = unionai_project.test.id
+  domain  = "production"
+
+  container {
+    image = "ghcr.io/acme/model-server:1.4.0"
+    args  = ["--port", "8080"]
+    ports = [8080]
+    env = {
+      LOG_LEVEL = "info"
+    }
+    requests = {
+      cpu    = "2"
+      memory = "8Gi"
+      gpu    = "1"
+    }
+  }
+
+  autoscaling {
+    min_replicas     = 0
+    max_replicas     = 4
+    scaledown_period = "15m"
+    concurrency      = 8
+  }
+
+  ingress {
+    subdomain = "models"
+  }
+
+  security_context {
+    run_as {
+      k8s_service_account = "model-server"
+    }
+    secret {
+      group             = "hf-token"
+      mount_requirement = "env_var"
+      env_var           = "HF_TOKEN"
+    }
+  }
+
+  input {
+    name  = "model_uri"
+    value = "s3://acme-models/llm/v3"
+  }
+
+  # Loading the model can take a while; include more logs if it fails
+  failure_log_lines = 100
+  timeouts {
+    create = "45m"
+    update = "30m"
+  }
+}
+
+# Internal dashboard defined as a pod, calling the model server
+resource "unionai_serving_app" "dashboard" {
+  name          = "dashboard"
+  project       = unionai_project.test.id
+  domain        = "production"
+  desired_state = "active"
+  cluster_pool  = "cpu-pool"
+
+  pod {
+    primary_container_name = "dashboard"
+    pod_spec = jsonencode({
+      containers = [{
+        name  = "dashboard"
+        image = "ghcr.io/acme/dashboard:2.1.0"
+        ports = [{ containerPort = 8501 }]
+      }]
+    })
+  }
+
+  ingress {
+    private = true
+  }
+
+  input {
+    name   = "backend"
+    app_id = unionai_serving_app.model_server.id
+  }
+}
+
+output "model_server_url" {
+  value = unionai_serving_app.model_server.public_url
+}
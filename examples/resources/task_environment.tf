@@ -3,6 +3,18 @@ resource "unionai_task_environment" "env" {
   path    = "./v2_task/hello.py"
   project = "nelson"
   domain  = "development"
+
+  # Only recalculate the version when these files change
+  source_dir = "./v2_task"
+  include    = ["**/*.py", "requirements.txt"]
+}
+
+# Deploy a task spec bundle emitted by the SDK, without the flyte CLI
+resource "unionai_task_environment" "bundle" {
+  id        = "hello_world"
+  spec_file = "./v2_task/hello_world.json"
+  project   = "nelson"
+  domain    = "development"
 }
 
 output "task_environment" {
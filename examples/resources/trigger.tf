@@ -0,0 +1,36 @@
+# Run the nightly training task every day at 02:00 Berlin time
+resource "unionai_trigger" "nightly" {
+  name                   = "nightly"
+  project                = unionai_project.test.id
+  domain                 = "production"
+  task_name              = "training_env.train"
+  description            = "Nightly retraining"
+  kickoff_time_input_arg = "kickoff_time"
+
+  cron {
+    expression = "0 2 * * *"
+    timezone   = "Europe/Berlin"
+  }
+
+  labels = {
+    team = "ml"
+  }
+  envs = {
+    LOG_LEVEL = "info"
+  }
+}
+
+# Poll every 15 minutes on a pinned task version, currently paused
+resource "unionai_trigger" "poll" {
+  name         = "poll"
+  project      = unionai_project.test.id
+  domain       = "development"
+  task_name    = "ingest_env.poll"
+  task_version = "v1.4.0"
+  active       = false
+
+  fixed_rate {
+    value = 15
+    unit  = "FIXED_RATE_UNIT_MINUTE"
+  }
+}
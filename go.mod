@@ -2,11 +2,15 @@ module github.com/unionai/terraform-provider-unionai
 
 go 1.24.6
 
-replace github.com/unionai/cloud/gen/pb-go => ./proto/union
+replace (
+	github.com/flyteorg/flyte/v2/gen/go/flyteidl2 => ./proto/flyteidl2
+	github.com/unionai/cloud/gen/pb-go => ./proto/union
+)
 
 require (
 	github.com/coreos/go-oidc/v3 v3.15.0
 	github.com/flyteorg/flyte/flyteidl v1.16.1
+	github.com/flyteorg/flyte/v2/gen/go/flyteidl2 v0.0.0-00010101000000-000000000000
 	github.com/hashicorp/terraform-plugin-framework v1.16.1
 	github.com/hashicorp/terraform-plugin-go v0.29.0
 	github.com/hashicorp/terraform-plugin-log v0.9.0
@@ -14,6 +18,7 @@ require (
 	github.com/unionai/cloud/gen/pb-go v0.0.0-00010101000000-000000000000
 	golang.org/x/oauth2 v0.30.0
 	google.golang.org/grpc v1.75.1
+	google.golang.org/protobuf v1.36.9
 )
 
 require (
@@ -71,5 +76,4 @@ require (
 	google.golang.org/appengine v1.6.8 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20250908214217-97024824d090 // indirect
 	google.golang.org/genproto/googleapis/rpc v0.0.0-20250908214217-97024824d090 // indirect
-	google.golang.org/protobuf v1.36.9 // indirect
 )
@@ -0,0 +1,253 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/authorizer"
+	"github.com/unionai/cloud/gen/pb-go/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &AuthorizationCheckDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &AuthorizationCheckDataSource{}
+
+func NewAuthorizationCheckDataSource() datasource.DataSource {
+	return &AuthorizationCheckDataSource{}
+}
+
+// AuthorizationCheckDataSource asks the AuthorizerService whether a principal
+// may perform an action on a resource.
+type AuthorizationCheckDataSource struct {
+	conn    authorizer.AuthorizerServiceClient
+	org     string
+	domains []string
+}
+
+// AuthorizationCheckDataSourceModel describes the data source data model.
+type AuthorizationCheckDataSourceModel struct {
+	User        types.String `tfsdk:"user"`
+	Application types.String `tfsdk:"application"`
+	Action      types.String `tfsdk:"action"`
+	Project     types.String `tfsdk:"project"`
+	Domain      types.String `tfsdk:"domain"`
+	Workflow    types.String `tfsdk:"workflow"`
+	LaunchPlan  types.String `tfsdk:"launch_plan"`
+	Cluster     types.String `tfsdk:"cluster"`
+	Allowed     types.Bool   `tfsdk:"allowed"`
+}
+
+func (d *AuthorizationCheckDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_authorization_check"
+}
+
+func (d *AuthorizationCheckDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Authorization check data source",
+
+		Attributes: map[string]schema.Attribute{
+			"user": schema.StringAttribute{
+				MarkdownDescription: "Subject of the user to check. Conflicts with application",
+				Optional:            true,
+			},
+			"application": schema.StringAttribute{
+				MarkdownDescription: "Client ID of the application to check. Conflicts with user",
+				Optional:            true,
+			},
+			"action": schema.StringAttribute{
+				MarkdownDescription: "Action to check, such as create_flyte_executions",
+				Required:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the resource. Requires domain",
+				Optional:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain of the resource",
+				Optional:            true,
+			},
+			"workflow": schema.StringAttribute{
+				MarkdownDescription: "Workflow name. Requires project and domain",
+				Optional:            true,
+			},
+			"launch_plan": schema.StringAttribute{
+				MarkdownDescription: "Launch plan name. Requires project and domain",
+				Optional:            true,
+			},
+			"cluster": schema.StringAttribute{
+				MarkdownDescription: "Cluster name. Conflicts with the other resource attributes",
+				Optional:            true,
+			},
+			"allowed": schema.BoolAttribute{
+				MarkdownDescription: "Whether the principal may perform the action on the resource",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *AuthorizationCheckDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.conn = authorizer.NewAuthorizerServiceClient(client.conn)
+	if d.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *authorizer.AuthorizerServiceClient, got: %T. Please report this issue to the provider developers.", d.conn),
+		)
+		return
+	}
+	d.org = client.org
+	d.domains = domainIds(client.domains)
+}
+
+func (d *AuthorizationCheckDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data AuthorizationCheckDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.User.IsUnknown() && !data.Application.IsUnknown() && data.User.IsNull() == data.Application.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("user"),
+			"Invalid Principal",
+			"Exactly one of user or application must be set.",
+		)
+	}
+
+	scoped := !data.Workflow.IsNull() || !data.LaunchPlan.IsNull()
+	switch {
+	case !data.Workflow.IsNull() && !data.LaunchPlan.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("workflow"), "Invalid Resource", "Only one of workflow or launch_plan can be set.")
+	case scoped && (data.Project.IsNull() || data.Domain.IsNull()):
+		resp.Diagnostics.AddAttributeError(path.Root("project"), "Invalid Resource", "workflow and launch_plan require project and domain.")
+	case !data.Project.IsNull() && data.Domain.IsNull():
+		resp.Diagnostics.AddAttributeError(path.Root("domain"), "Invalid Resource", "project requires domain.")
+	case !data.Cluster.IsNull() && (scoped || !data.Project.IsNull() || !data.Domain.IsNull()):
+		resp.Diagnostics.AddAttributeError(path.Root("cluster"), "Invalid Resource", "cluster cannot be combined with the other resource attributes.")
+	}
+}
+
+// authorizationResource builds the resource to check from the most specific
+// attribute set, falling back to the organization.
+func (d *AuthorizationCheckDataSource) authorizationResource(data *AuthorizationCheckDataSourceModel) (*common.Resource, error) {
+	if !data.Cluster.IsNull() {
+		return &common.Resource{
+			Resource: &common.Resource_Cluster{
+				Cluster: &common.ClusterIdentifier{
+					Name:         data.Cluster.ValueString(),
+					Organization: d.org,
+				},
+			},
+		}, nil
+	}
+	if data.Domain.IsNull() {
+		return &common.Resource{
+			Resource: &common.Resource_Organization{
+				Organization: &common.Organization{
+					Name: d.org,
+				},
+			},
+		}, nil
+	}
+
+	if err := validateDomain(d.domains, data.Domain.ValueString()); err != nil {
+		return nil, err
+	}
+	domain := &common.Domain{
+		Name: data.Domain.ValueString(),
+		Organization: &common.Organization{
+			Name: d.org,
+		},
+	}
+	if data.Project.IsNull() {
+		return &common.Resource{Resource: &common.Resource_Domain{Domain: domain}}, nil
+	}
+	project := &common.Project{
+		Name:   data.Project.ValueString(),
+		Domain: domain,
+	}
+	switch {
+	case !data.Workflow.IsNull():
+		return &common.Resource{
+			Resource: &common.Resource_Workflow{
+				Workflow: &common.Workflow{
+					Name:    data.Workflow.ValueString(),
+					Project: project,
+				},
+			},
+		}, nil
+	case !data.LaunchPlan.IsNull():
+		return &common.Resource{
+			Resource: &common.Resource_LaunchPlan{
+				LaunchPlan: &common.LaunchPlan{
+					Name:    data.LaunchPlan.ValueString(),
+					Project: project,
+				},
+			},
+		}, nil
+	}
+	return &common.Resource{Resource: &common.Resource_Project{Project: project}}, nil
+}
+
+func (d *AuthorizationCheckDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data AuthorizationCheckDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	actions, err := roleActions([]string{data.Action.ValueString()})
+	if err != nil {
+		resp.Diagnostics.AddError("Action does not exist", err.Error())
+		return
+	}
+	res, err := d.authorizationResource(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Domain", err.Error())
+		return
+	}
+	identity := userIdentity(data.User.ValueString())
+	if !data.Application.IsNull() {
+		identity = applicationIdentity(data.Application.ValueString())
+	}
+
+	result, err := d.conn.Authorize(ctx, &authorizer.AuthorizeRequest{
+		Organization: d.org,
+		Identity:     identity,
+		Resource:     res,
+		Action:       actions[0],
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to check authorization", err.Error())
+		return
+	}
+	data.Allowed = types.BoolValue(result.GetAllowed())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestAuthorizationCheckDataSource_Resource(t *testing.T) {
+	d := &AuthorizationCheckDataSource{org: "test-org", domains: []string{"development", "production"}}
+	data := &AuthorizationCheckDataSourceModel{
+		Project:    types.StringNull(),
+		Domain:     types.StringNull(),
+		Workflow:   types.StringNull(),
+		LaunchPlan: types.StringNull(),
+		Cluster:    types.StringNull(),
+	}
+
+	res, err := d.authorizationResource(data)
+	if err != nil || res.GetOrganization().GetName() != "test-org" {
+		t.Errorf("Expected the organization without a resource, got %v (%v)", res, err)
+	}
+
+	data.Project = types.StringValue("ml")
+	data.Domain = types.StringValue("development")
+	res, err = d.authorizationResource(data)
+	if err != nil || res.GetProject().GetName() != "ml" || res.GetProject().GetDomain().GetName() != "development" {
+		t.Errorf("Expected the project, got %v (%v)", res, err)
+	}
+
+	data.LaunchPlan = types.StringValue("nightly")
+	res, err = d.authorizationResource(data)
+	if err != nil || res.GetLaunchPlan().GetName() != "nightly" || res.GetLaunchPlan().GetProject().GetName() != "ml" {
+		t.Errorf("Expected the launch plan, got %v (%v)", res, err)
+	}
+
+	data.Domain = types.StringValue("qa")
+	if _, err := d.authorizationResource(data); err == nil {
+		t.Error("Expected a domain missing from the organization to be rejected")
+	}
+}
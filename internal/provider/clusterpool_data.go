@@ -1 +1,150 @@
 package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ClusterPoolDataSource{}
+
+func NewClusterPoolDataSource() datasource.DataSource {
+	return &ClusterPoolDataSource{}
+}
+
+// ClusterPoolDataSource defines the data source implementation. Cluster pools
+// have no service of their own; they are derived from the pools each
+// dataplane reports it belongs to.
+type ClusterPoolDataSource struct {
+	conn cluster.ClusterServiceClient
+	org  string
+}
+
+// ClusterPoolDataSourceModel describes the data source data model.
+type ClusterPoolDataSourceModel struct {
+	Name       types.String `tfsdk:"name"`
+	Dataplanes types.Set    `tfsdk:"dataplanes"`
+}
+
+func (d *ClusterPoolDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_cluster_pool"
+}
+
+func (d *ClusterPoolDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Cluster pool data source",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Cluster pool name",
+				Required:            true,
+			},
+			"dataplanes": schema.SetAttribute{
+				MarkdownDescription: "IDs of the dataplanes in the cluster pool",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *ClusterPoolDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.conn = cluster.NewClusterServiceClient(client.conn)
+	if d.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cluster.ClusterServiceClient, got: %T. Please report this issue to the provider developers.", d.conn),
+		)
+		return
+	}
+	d.org = client.org
+}
+
+func (d *ClusterPoolDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ClusterPoolDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := listClusters(ctx, d.conn, d.org)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch clusters", err.Error())
+		return
+	}
+
+	members := clusterPoolMembers(clusters)[data.Name.ValueString()]
+	if len(members) == 0 {
+		resp.Diagnostics.AddError("Cluster pool not found", fmt.Sprintf("No dataplane belongs to cluster pool %s", data.Name.ValueString()))
+		return
+	}
+	data.Dataplanes = convertStringsToSet(members)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// listClusters pages through all clusters of the organization.
+func listClusters(ctx context.Context, conn cluster.ClusterServiceClient, org string) ([]*cluster.Cluster, error) {
+	var clusters []*cluster.Cluster
+	token := ""
+	for {
+		resp, err := conn.ListClusters(ctx, &cluster.ListRequest{
+			Organization: org,
+			Request: &common.ListRequest{
+				Limit: 100,
+				Token: token,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, resp.GetClusters()...)
+		if resp.GetToken() == "" || len(resp.GetClusters()) == 0 {
+			return clusters, nil
+		}
+		token = resp.GetToken()
+	}
+}
+
+// clusterPoolMembers maps each cluster pool to the sorted names of the
+// clusters that belong to it.
+func clusterPoolMembers(clusters []*cluster.Cluster) map[string][]string {
+	members := map[string][]string{}
+	for _, c := range clusters {
+		for _, pool := range c.GetPools() {
+			members[pool.GetName()] = append(members[pool.GetName()], c.GetSpec().GetId().GetName())
+		}
+	}
+	for _, names := range members {
+		sort.Strings(names)
+	}
+	return members
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc"
+)
+
+// mockClusterClient implements the subset of cluster.ClusterServiceClient used
+// by the dataplane resources and data sources. Each page holds the clusters
+// returned for one ListClusters call, and each GetCluster call consumes the
+// next of gets, repeating the last one.
+type mockClusterClient struct {
+	cluster.ClusterServiceClient
+	pages [][]*cluster.Cluster
+	gets  []mockClusterGet
+	calls int
+}
+
+type mockClusterGet struct {
+	cluster *cluster.Cluster
+	err     error
+}
+
+func (m *mockClusterClient) GetCluster(ctx context.Context, in *cluster.GetRequest, opts ...grpc.CallOption) (*cluster.GetResponse, error) {
+	get := m.gets[min(m.calls, len(m.gets)-1)]
+	m.calls++
+	if get.err != nil {
+		return nil, get.err
+	}
+	return &cluster.GetResponse{Cluster: get.cluster}, nil
+}
+
+func (m *mockClusterClient) ListClusters(ctx context.Context, in *cluster.ListRequest, opts ...grpc.CallOption) (*cluster.ListResponse, error) {
+	page := 0
+	if in.GetRequest().GetToken() != "" {
+		page = int(in.GetRequest().GetToken()[0] - '0')
+	}
+	resp := &cluster.ListResponse{Clusters: m.pages[page]}
+	if page+1 < len(m.pages) {
+		resp.Token = string(rune('0' + page + 1))
+	}
+	return resp, nil
+}
+
+func clusterInPools(name string, pools ...string) *cluster.Cluster {
+	c := &cluster.Cluster{Spec: &cluster.Spec{Id: &common.ClusterIdentifier{Name: name, Organization: "test-org"}}}
+	for _, pool := range pools {
+		c.Pools = append(c.Pools, &common.ClusterPoolIdentifier{Organization: "test-org", Name: pool})
+	}
+	return c
+}
+
+func TestClusterPoolMembers(t *testing.T) {
+	conn := &mockClusterClient{pages: [][]*cluster.Cluster{
+		{clusterInPools("dp-2", "gpu", "default"), clusterInPools("dp-3")},
+		{clusterInPools("dp-1", "gpu")},
+	}}
+
+	clusters, err := listClusters(context.Background(), conn, "test-org")
+	if err != nil {
+		t.Fatalf("listClusters() returned error: %s", err)
+	}
+	if len(clusters) != 3 {
+		t.Fatalf("Expected clusters from both pages, got %v", clusters)
+	}
+
+	members := clusterPoolMembers(clusters)
+	expected := map[string][]string{
+		"gpu":     {"dp-1", "dp-2"},
+		"default": {"dp-2"},
+	}
+	if !reflect.DeepEqual(members, expected) {
+		t.Errorf("Expected members %v, got %v", expected, members)
+	}
+}
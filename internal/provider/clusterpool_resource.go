@@ -1 +1,5 @@
 package provider
+
+// Cluster pools and their membership are assigned by Union.ai and the API has
+// no service to create pools or change which dataplanes belong to them, so
+// they are only exposed through the unionai_cluster_pool data source.
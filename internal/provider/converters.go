@@ -1,8 +1,11 @@
 package provider
 
 import (
+	"time"
+
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func convertSetToStrings(input types.Set) []string {
@@ -28,3 +31,79 @@ func convertArrayToSetGetter[T any](input []T, getter func(T) string) types.Set
 	}
 	return types.SetValueMust(types.StringType, output)
 }
+
+func convertListToStrings(input types.List) []string {
+	output := make([]string, 0, len(input.Elements()))
+	for _, item := range input.Elements() {
+		output = append(output, item.(types.String).ValueString())
+	}
+	return output
+}
+
+// convertStringsToList returns a null list for empty input, so optional list
+// attributes that were never configured do not show a diff.
+func convertStringsToList(input []string) types.List {
+	if len(input) == 0 {
+		return types.ListNull(types.StringType)
+	}
+	output := make([]attr.Value, 0, len(input))
+	for _, item := range input {
+		output = append(output, types.StringValue(item))
+	}
+	return types.ListValueMust(types.StringType, output)
+}
+
+func convertMapToStrings(input types.Map) map[string]string {
+	output := make(map[string]string, len(input.Elements()))
+	for key, item := range input.Elements() {
+		output[key] = item.(types.String).ValueString()
+	}
+	return output
+}
+
+// convertStringsToMap returns a null map for empty input, so optional map
+// attributes that were never configured do not show a diff.
+func convertStringsToMap(input map[string]string) types.Map {
+	if len(input) == 0 {
+		return types.MapNull(types.StringType)
+	}
+	output := make(map[string]attr.Value, len(input))
+	for key, item := range input {
+		output[key] = types.StringValue(item)
+	}
+	return types.MapValueMust(types.StringType, output)
+}
+
+func convertTimestampToString(input *timestamppb.Timestamp) types.String {
+	if input == nil {
+		return types.StringNull()
+	}
+	return types.StringValue(input.AsTime().Format(time.RFC3339))
+}
+
+// optionalString returns a null string for empty input, mirroring how unset
+// optional attributes are stored.
+func optionalString(input string) types.String {
+	if input == "" {
+		return types.StringNull()
+	}
+	return types.StringValue(input)
+}
+
+// optionalBool returns a null bool for false, unless the attribute was
+// configured, mirroring how unset optional attributes are stored.
+func optionalBool(prior types.Bool, value bool) types.Bool {
+	if !value && prior.IsNull() {
+		return types.BoolNull()
+	}
+	return types.BoolValue(value)
+}
+
+// optionalInt64 returns a null number for zero, unless the attribute was
+// configured.
+func optionalInt64(prior types.Int64, value int64) types.Int64 {
+	if value == 0 && prior.IsNull() {
+		return types.Int64Null()
+	}
+	return types.Int64Value(value)
+}
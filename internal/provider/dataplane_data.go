@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/unionai/cloud/gen/pb-go/cluster"
@@ -16,6 +19,7 @@ import (
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ datasource.DataSource = &DataplaneDataSource{}
+var _ datasource.DataSourceWithValidateConfig = &DataplaneDataSource{}
 
 func NewDataplaneDataSource() datasource.DataSource {
 	return &DataplaneDataSource{}
@@ -29,9 +33,77 @@ type DataplaneDataSource struct {
 
 // DataplaneDataSourceModel describes the data source data model.
 type DataplaneDataSourceModel struct {
-	Id     types.String `tfsdk:"id"`
-	State  types.String `tfsdk:"state"`
-	Health types.String `tfsdk:"health"`
+	Id                types.String                     `tfsdk:"id"`
+	WaitForHealthy    types.Bool                       `tfsdk:"wait_for_healthy"`
+	Timeout           types.String                     `tfsdk:"timeout"`
+	State             types.String                     `tfsdk:"state"`
+	Health            types.String                     `tfsdk:"health"`
+	AssignedConfigId  types.String                     `tfsdk:"assigned_config_id"`
+	UnhealthyReasons  types.List                       `tfsdk:"unhealthy_reasons"`
+	Capabilities      *DataplaneCapabilitiesModel      `tfsdk:"capabilities"`
+	SnapshotAggregate *DataplaneSnapshotAggregateModel `tfsdk:"snapshot_aggregate"`
+	MonitoringInfo    []DataplaneMonitoringInfoModel   `tfsdk:"monitoring_info"`
+	IdentityInfo      *DataplaneIdentityInfoModel      `tfsdk:"identity_info"`
+	SyncedConfig      *DataplaneSyncedConfigModel      `tfsdk:"synced_config"`
+}
+
+type DataplaneCapabilitiesModel struct {
+	PropellerConfigVersion types.String                 `tfsdk:"propeller_config_version"`
+	Capacity               *DataplaneResourcesModel     `tfsdk:"capacity"`
+	Consumed               *DataplaneResourcesModel     `tfsdk:"consumed"`
+	ExecutionLoad          *DataplaneExecutionLoadModel `tfsdk:"execution_load"`
+}
+
+type DataplaneResourcesModel struct {
+	CpuUnscaled          types.Int64 `tfsdk:"cpu_unscaled"`
+	GpuUnscaled          types.Int64 `tfsdk:"gpu_unscaled"`
+	MemoryMega           types.Int64 `tfsdk:"memory_mega"`
+	StorageMega          types.Int64 `tfsdk:"storage_mega"`
+	EphemeralStorageMega types.Int64 `tfsdk:"ephemeral_storage_mega"`
+	AdditionalResources  types.Map   `tfsdk:"additional_resources"`
+}
+
+type DataplaneExecutionLoadModel struct {
+	ActiveExecutions     types.Int64 `tfsdk:"active_executions"`
+	ActiveNodeExecutions types.Int64 `tfsdk:"active_node_executions"`
+	ActiveTaskExecutions types.Int64 `tfsdk:"active_task_executions"`
+}
+
+type DataplaneSnapshotAggregateModel struct {
+	StartAt      types.String                 `tfsdk:"start_at"`
+	EndAt        types.String                 `tfsdk:"end_at"`
+	ResourceInfo []DataplaneResourceInfoModel `tfsdk:"resource_info"`
+}
+
+type DataplaneResourceInfoModel struct {
+	Project        types.String                    `tfsdk:"project"`
+	Domain         types.String                    `tfsdk:"domain"`
+	Used           *DataplaneResourceSnapshotModel `tfsdk:"used"`
+	Allocated      *DataplaneResourceSnapshotModel `tfsdk:"allocated"`
+	BillableSecond *DataplaneResourceSnapshotModel `tfsdk:"billable_second"`
+}
+
+type DataplaneResourceSnapshotModel struct {
+	Nanocpu    types.Int64 `tfsdk:"nanocpu"`
+	Nanogpu    types.Int64 `tfsdk:"nanogpu"`
+	MemoryByte types.Int64 `tfsdk:"memory_byte"`
+	Nanogpus   types.Map   `tfsdk:"nanogpus"`
+}
+
+type DataplaneMonitoringInfoModel struct {
+	Name                types.String `tfsdk:"name"`
+	Health              types.String `tfsdk:"health"`
+	UnhealthyReason     types.String `tfsdk:"unhealthy_reason"`
+	ConsecutiveFailures types.Int64  `tfsdk:"consecutive_failures"`
+}
+
+type DataplaneIdentityInfoModel struct {
+	AppId types.String `tfsdk:"app_id"`
+}
+
+type DataplaneSyncedConfigModel struct {
+	ConfigId types.String `tfsdk:"config_id"`
+	SyncedAt types.String `tfsdk:"synced_at"`
 }
 
 func (d *DataplaneDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
@@ -39,6 +111,53 @@ func (d *DataplaneDataSource) Metadata(ctx context.Context, req datasource.Metad
 }
 
 func (d *DataplaneDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resourcesAttributes := map[string]schema.Attribute{
+		"cpu_unscaled": schema.Int64Attribute{
+			MarkdownDescription: "CPU, unscaled",
+			Computed:            true,
+		},
+		"gpu_unscaled": schema.Int64Attribute{
+			MarkdownDescription: "GPUs, unscaled",
+			Computed:            true,
+		},
+		"memory_mega": schema.Int64Attribute{
+			MarkdownDescription: "Memory, in megabytes",
+			Computed:            true,
+		},
+		"storage_mega": schema.Int64Attribute{
+			MarkdownDescription: "Storage, in megabytes",
+			Computed:            true,
+		},
+		"ephemeral_storage_mega": schema.Int64Attribute{
+			MarkdownDescription: "Ephemeral storage, in megabytes",
+			Computed:            true,
+		},
+		"additional_resources": schema.MapAttribute{
+			MarkdownDescription: "Additional resources, keyed by resource name",
+			Computed:            true,
+			ElementType:         types.Int64Type,
+		},
+	}
+	snapshotAttributes := map[string]schema.Attribute{
+		"nanocpu": schema.Int64Attribute{
+			MarkdownDescription: "CPU, in billionths of a core",
+			Computed:            true,
+		},
+		"nanogpu": schema.Int64Attribute{
+			MarkdownDescription: "GPUs, in billionths of a GPU",
+			Computed:            true,
+		},
+		"memory_byte": schema.Int64Attribute{
+			MarkdownDescription: "Memory, in bytes",
+			Computed:            true,
+		},
+		"nanogpus": schema.MapAttribute{
+			MarkdownDescription: "GPUs by type, in billionths of a GPU",
+			Computed:            true,
+			ElementType:         types.Int64Type,
+		},
+	}
+
 	resp.Schema = schema.Schema{
 		// This description is used by the documentation generator and the language server.
 		MarkdownDescription: "Cluster data source",
@@ -48,6 +167,14 @@ func (d *DataplaneDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				MarkdownDescription: "Cluster identifier",
 				Required:            true,
 			},
+			"wait_for_healthy": schema.BoolAttribute{
+				MarkdownDescription: "Wait until the dataplane is healthy and enabled. A dataplane that has not registered yet is waited for as well",
+				Optional:            true,
+			},
+			"timeout": schema.StringAttribute{
+				MarkdownDescription: "How long to wait for the dataplane, as a Go duration (e.g. `30m`). Defaults to `20m`",
+				Optional:            true,
+			},
 			"state": schema.StringAttribute{
 				MarkdownDescription: "Cluster state",
 				Computed:            true,
@@ -56,6 +183,146 @@ func (d *DataplaneDataSource) Schema(ctx context.Context, req datasource.SchemaR
 				MarkdownDescription: "Dataplane health",
 				Computed:            true,
 			},
+			"assigned_config_id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the config assigned to the dataplane",
+				Computed:            true,
+			},
+			"unhealthy_reasons": schema.ListAttribute{
+				MarkdownDescription: "Reasons the dataplane is unhealthy",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"capabilities": schema.SingleNestedAttribute{
+				MarkdownDescription: "Capabilities reported by the dataplane",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"propeller_config_version": schema.StringAttribute{
+						MarkdownDescription: "Version of the propeller config",
+						Computed:            true,
+					},
+					"capacity": schema.SingleNestedAttribute{
+						MarkdownDescription: "Resource capacity of the dataplane",
+						Computed:            true,
+						Attributes:          resourcesAttributes,
+					},
+					"consumed": schema.SingleNestedAttribute{
+						MarkdownDescription: "Resources consumed on the dataplane",
+						Computed:            true,
+						Attributes:          resourcesAttributes,
+					},
+					"execution_load": schema.SingleNestedAttribute{
+						MarkdownDescription: "Executions running on the dataplane",
+						Computed:            true,
+						Attributes: map[string]schema.Attribute{
+							"active_executions": schema.Int64Attribute{
+								MarkdownDescription: "Number of active executions",
+								Computed:            true,
+							},
+							"active_node_executions": schema.Int64Attribute{
+								MarkdownDescription: "Number of active node executions",
+								Computed:            true,
+							},
+							"active_task_executions": schema.Int64Attribute{
+								MarkdownDescription: "Number of active task executions",
+								Computed:            true,
+							},
+						},
+					},
+				},
+			},
+			"snapshot_aggregate": schema.SingleNestedAttribute{
+				MarkdownDescription: "Resource usage aggregated over the last snapshot window",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"start_at": schema.StringAttribute{
+						MarkdownDescription: "Start of the window",
+						Computed:            true,
+					},
+					"end_at": schema.StringAttribute{
+						MarkdownDescription: "End of the window",
+						Computed:            true,
+					},
+					"resource_info": schema.ListNestedAttribute{
+						MarkdownDescription: "Resource usage per project and domain",
+						Computed:            true,
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"project": schema.StringAttribute{
+									MarkdownDescription: "Project",
+									Computed:            true,
+								},
+								"domain": schema.StringAttribute{
+									MarkdownDescription: "Domain",
+									Computed:            true,
+								},
+								"used": schema.SingleNestedAttribute{
+									MarkdownDescription: "Resources used",
+									Computed:            true,
+									Attributes:          snapshotAttributes,
+								},
+								"allocated": schema.SingleNestedAttribute{
+									MarkdownDescription: "Resources allocated",
+									Computed:            true,
+									Attributes:          snapshotAttributes,
+								},
+								"billable_second": schema.SingleNestedAttribute{
+									MarkdownDescription: "Billable resource seconds",
+									Computed:            true,
+									Attributes:          snapshotAttributes,
+								},
+							},
+						},
+					},
+				},
+			},
+			"monitoring_info": schema.ListNestedAttribute{
+				MarkdownDescription: "Health of the components monitored on the dataplane",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Component name",
+							Computed:            true,
+						},
+						"health": schema.StringAttribute{
+							MarkdownDescription: "Component health",
+							Computed:            true,
+						},
+						"unhealthy_reason": schema.StringAttribute{
+							MarkdownDescription: "Reason the component is unhealthy",
+							Computed:            true,
+						},
+						"consecutive_failures": schema.Int64Attribute{
+							MarkdownDescription: "Number of consecutive failed checks",
+							Computed:            true,
+						},
+					},
+				},
+			},
+			"identity_info": schema.SingleNestedAttribute{
+				MarkdownDescription: "Identity of the dataplane",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"app_id": schema.StringAttribute{
+						MarkdownDescription: "Application the dataplane authenticates as",
+						Computed:            true,
+					},
+				},
+			},
+			"synced_config": schema.SingleNestedAttribute{
+				MarkdownDescription: "Config last synced to the dataplane",
+				Computed:            true,
+				Attributes: map[string]schema.Attribute{
+					"config_id": schema.StringAttribute{
+						MarkdownDescription: "Identifier of the synced config",
+						Computed:            true,
+					},
+					"synced_at": schema.StringAttribute{
+						MarkdownDescription: "When the config was synced",
+						Computed:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -88,6 +355,26 @@ func (d *DataplaneDataSource) Configure(ctx context.Context, req datasource.Conf
 	d.org = client.org
 }
 
+func (d *DataplaneDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	var data DataplaneDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Timeout.IsNull() || data.Timeout.IsUnknown() {
+		return
+	}
+	if _, err := time.ParseDuration(data.Timeout.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("timeout"),
+			"Invalid Timeout",
+			fmt.Sprintf("Unable to parse timeout, got error: %s", err),
+		)
+	}
+}
+
 func (d *DataplaneDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	var data DataplaneDataSourceModel
 
@@ -98,26 +385,143 @@ func (d *DataplaneDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	// Read cluster
-	c, err := d.conn.GetCluster(context.Background(), &cluster.GetRequest{
-		ClusterId: &common.ClusterIdentifier{
-			Name:         data.Id.ValueString(),
-			Organization: d.org,
-		},
-	})
-	if err != nil {
-		if status.Code(err) == codes.NotFound {
-			resp.Diagnostics.AddError("Dataplane not found", fmt.Sprintf("Dataplane with ID %s not found", data.Id.ValueString()))
+	if data.WaitForHealthy.ValueBool() {
+		timeout := defaultDataplaneWaitTimeout
+		if !data.Timeout.IsNull() {
+			timeout, _ = time.ParseDuration(data.Timeout.ValueString())
+		}
+		c, err := waitForHealthyDataplane(ctx, d.conn, d.org, data.Id.ValueString(), timeout)
+		if err != nil {
+			resp.Diagnostics.AddError("Dataplane not healthy", err.Error())
 			return
 		}
-		resp.Diagnostics.AddError("Failed to fetch dataplane", err.Error())
-		return
-	}
-	tflog.Trace(ctx, "GetCluster response", map[string]interface{}{"cluster": c})
+		refreshDataplane(&data, c)
+	} else {
+		// Read cluster
+		c, err := d.conn.GetCluster(context.Background(), &cluster.GetRequest{
+			ClusterId: &common.ClusterIdentifier{
+				Name:         data.Id.ValueString(),
+				Organization: d.org,
+			},
+		})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				resp.Diagnostics.AddError("Dataplane not found", fmt.Sprintf("Dataplane with ID %s not found", data.Id.ValueString()))
+				return
+			}
+			resp.Diagnostics.AddError("Failed to fetch dataplane", err.Error())
+			return
+		}
+		tflog.Trace(ctx, "GetCluster response", map[string]interface{}{"cluster": c})
 
-	data.Health = types.StringValue(cluster.Status_Health_name[int32(c.Cluster.Status.Health)])
-	data.State = types.StringValue(cluster.State_name[int32(c.Cluster.Status.State)])
+		refreshDataplane(&data, c.GetCluster())
+	}
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
+
+// refreshDataplane copies the spec and status of the cluster into the model.
+func refreshDataplane(data *DataplaneDataSourceModel, c *cluster.Cluster) {
+	s := c.GetStatus()
+	data.Health = types.StringValue(cluster.Status_Health_name[int32(s.GetHealth())])
+	data.State = types.StringValue(cluster.State_name[int32(s.GetState())])
+	data.AssignedConfigId = optionalString(c.GetSpec().GetAssignedConfigId())
+
+	reasons := make([]attr.Value, 0, len(s.GetUnhealthyReasons()))
+	for _, reason := range s.GetUnhealthyReasons() {
+		reasons = append(reasons, types.StringValue(reason))
+	}
+	data.UnhealthyReasons = types.ListValueMust(types.StringType, reasons)
+
+	data.Capabilities = nil
+	if capabilities := s.GetCapabilities(); capabilities != nil {
+		data.Capabilities = &DataplaneCapabilitiesModel{
+			PropellerConfigVersion: optionalString(capabilities.GetPropellerConfigVersion()),
+			Capacity:               dataplaneResources(capabilities.GetResources().GetCapacity()),
+			Consumed:               dataplaneResources(capabilities.GetResources().GetConsumed()),
+		}
+		if load := capabilities.GetExecutionLoad(); load != nil {
+			data.Capabilities.ExecutionLoad = &DataplaneExecutionLoadModel{
+				ActiveExecutions:     types.Int64Value(int64(load.GetActiveExecutionsCount())),
+				ActiveNodeExecutions: types.Int64Value(int64(load.GetActiveNodeExecutionsCount())),
+				ActiveTaskExecutions: types.Int64Value(int64(load.GetActiveTaskExecutionsCount())),
+			}
+		}
+	}
+
+	data.SnapshotAggregate = nil
+	if aggregate := s.GetSnapshotAggregate(); aggregate != nil {
+		data.SnapshotAggregate = &DataplaneSnapshotAggregateModel{
+			StartAt:      convertTimestampToString(aggregate.GetStartAt()),
+			EndAt:        convertTimestampToString(aggregate.GetEndAt()),
+			ResourceInfo: []DataplaneResourceInfoModel{},
+		}
+		for _, info := range aggregate.GetResourceInfo() {
+			data.SnapshotAggregate.ResourceInfo = append(data.SnapshotAggregate.ResourceInfo, DataplaneResourceInfoModel{
+				Project:        optionalString(info.GetFlyteMetadata().GetProject()),
+				Domain:         optionalString(info.GetFlyteMetadata().GetDomain()),
+				Used:           dataplaneResourceSnapshot(info.GetUsed()),
+				Allocated:      dataplaneResourceSnapshot(info.GetAllocated()),
+				BillableSecond: dataplaneResourceSnapshot(info.GetBillableSecond()),
+			})
+		}
+	}
+
+	data.MonitoringInfo = []DataplaneMonitoringInfoModel{}
+	for _, info := range s.GetMonitoringInfo() {
+		data.MonitoringInfo = append(data.MonitoringInfo, DataplaneMonitoringInfoModel{
+			Name:                types.StringValue(info.GetName()),
+			Health:              types.StringValue(cluster.Status_Health_name[int32(info.GetHealth())]),
+			UnhealthyReason:     optionalString(info.GetUnhealthyReason()),
+			ConsecutiveFailures: types.Int64Value(int64(info.GetConsecutiveFailures())),
+		})
+	}
+
+	data.IdentityInfo = nil
+	if identity := s.GetIdentityInfo(); identity != nil {
+		data.IdentityInfo = &DataplaneIdentityInfoModel{AppId: optionalString(identity.GetAppId())}
+	}
+
+	data.SyncedConfig = nil
+	if synced := s.GetSyncedConfig(); synced != nil {
+		data.SyncedConfig = &DataplaneSyncedConfigModel{
+			ConfigId: optionalString(synced.GetConfigId()),
+			SyncedAt: convertTimestampToString(synced.GetSyncedAt()),
+		}
+	}
+}
+
+func dataplaneResources(r *cluster.Resources) *DataplaneResourcesModel {
+	if r == nil {
+		return nil
+	}
+	return &DataplaneResourcesModel{
+		CpuUnscaled:          types.Int64Value(r.GetCpuUnscaled()),
+		GpuUnscaled:          types.Int64Value(r.GetGpuUnscaled()),
+		MemoryMega:           types.Int64Value(r.GetMemoryMega()),
+		StorageMega:          types.Int64Value(r.GetStorageMega()),
+		EphemeralStorageMega: types.Int64Value(r.GetEphemeralStorageMega()),
+		AdditionalResources:  dataplaneInt64Map(r.GetAdditionalResources()),
+	}
+}
+
+func dataplaneResourceSnapshot(r *cluster.ResourceSnapshot) *DataplaneResourceSnapshotModel {
+	if r == nil {
+		return nil
+	}
+	return &DataplaneResourceSnapshotModel{
+		Nanocpu:    types.Int64Value(r.GetNanocpu()),
+		Nanogpu:    types.Int64Value(r.GetNanogpu()),
+		MemoryByte: types.Int64Value(r.GetMemoryByte()),
+		Nanogpus:   dataplaneInt64Map(r.GetNanogpus()),
+	}
+}
+
+func dataplaneInt64Map(input map[string]int64) types.Map {
+	values := make(map[string]attr.Value, len(input))
+	for key, value := range input {
+		values[key] = types.Int64Value(value)
+	}
+	return types.MapValueMust(types.Int64Type, values)
+}
@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestRefreshDataplane(t *testing.T) {
+	var data DataplaneDataSourceModel
+	refreshDataplane(&data, &cluster.Cluster{
+		Spec: &cluster.Spec{AssignedConfigId: "cfg-1"},
+		Status: &cluster.Status{
+			Health:           cluster.Status_UNHEALTHY,
+			State:            cluster.State_STATE_ENABLED,
+			UnhealthyReasons: []string{"propeller not reporting"},
+			Capabilities: &cluster.Capabilities{
+				Resources: &cluster.ClusterResources{
+					Capacity: &cluster.Resources{GpuUnscaled: 16, AdditionalResources: map[string]int64{"nvidia.com/mig-1g.5gb": 7}},
+				},
+				ExecutionLoad: &cluster.ExecutionLoad{ActiveExecutionsCount: 3},
+			},
+			MonitoringInfo: []*cluster.Status_MonitoringInfo{{Name: "propeller", Health: cluster.Status_UNHEALTHY, ConsecutiveFailures: 2}},
+		},
+	})
+
+	if data.Health.ValueString() != "UNHEALTHY" || data.State.ValueString() != "STATE_ENABLED" || data.AssignedConfigId.ValueString() != "cfg-1" {
+		t.Errorf("Unexpected health, state or config: %+v", data)
+	}
+	if len(data.UnhealthyReasons.Elements()) != 1 {
+		t.Errorf("Expected one unhealthy reason, got %v", data.UnhealthyReasons)
+	}
+	if data.Capabilities == nil || data.Capabilities.Capacity.GpuUnscaled.ValueInt64() != 16 || data.Capabilities.Consumed != nil {
+		t.Errorf("Unexpected capabilities: %+v", data.Capabilities)
+	}
+	if len(data.Capabilities.Capacity.AdditionalResources.Elements()) != 1 || data.Capabilities.ExecutionLoad.ActiveExecutions.ValueInt64() != 3 {
+		t.Errorf("Unexpected capacity or load: %+v", data.Capabilities)
+	}
+	if len(data.MonitoringInfo) != 1 || data.MonitoringInfo[0].Health.ValueString() != "UNHEALTHY" || !data.MonitoringInfo[0].UnhealthyReason.IsNull() {
+		t.Errorf("Unexpected monitoring info: %+v", data.MonitoringInfo)
+	}
+	if data.SnapshotAggregate != nil || data.IdentityInfo != nil || data.SyncedConfig != nil {
+		t.Errorf("Expected unreported status to be null: %+v", data)
+	}
+}
+
+func TestWaitForHealthyDataplane(t *testing.T) {
+	defer func(interval time.Duration) { dataplanePollInterval = interval }(dataplanePollInterval)
+	dataplanePollInterval = time.Millisecond
+
+	disabled := &cluster.Cluster{Status: &cluster.Status{Health: cluster.Status_HEALTHY, State: cluster.State_STATE_DISABLED}}
+	unhealthy := &cluster.Cluster{Status: &cluster.Status{
+		Health:           cluster.Status_UNHEALTHY,
+		State:            cluster.State_STATE_ENABLED,
+		UnhealthyReasons: []string{"operator not reporting"},
+	}}
+	conn := &mockClusterClient{gets: []mockClusterGet{
+		{err: status.Error(codes.NotFound, "not found")},
+		{cluster: unhealthy},
+		{cluster: disabled},
+		{cluster: &cluster.Cluster{Status: &cluster.Status{Health: cluster.Status_HEALTHY, State: cluster.State_STATE_ENABLED}}},
+	}}
+
+	if _, err := waitForHealthyDataplane(context.Background(), conn, "test-org", "dp-1", time.Second); err != nil {
+		t.Fatalf("waitForHealthyDataplane() returned error: %s", err)
+	}
+	if conn.calls != 4 {
+		t.Errorf("Expected 4 polls, got %d", conn.calls)
+	}
+
+	conn = &mockClusterClient{gets: []mockClusterGet{{cluster: unhealthy}}}
+	_, err := waitForHealthyDataplane(context.Background(), conn, "test-org", "dp-1", 20*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "operator not reporting") {
+		t.Errorf("Expected a timeout reporting the unhealthy reasons, got %v", err)
+	}
+
+	conn = &mockClusterClient{gets: []mockClusterGet{{err: status.Error(codes.PermissionDenied, "denied")}}}
+	if _, err := waitForHealthyDataplane(context.Background(), conn, "test-org", "dp-1", time.Second); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Expected other errors to stop the wait, got %v", err)
+	}
+}
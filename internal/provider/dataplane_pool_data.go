@@ -1 +1,117 @@
 package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &DataplanePoolsDataSource{}
+
+func NewDataplanePoolsDataSource() datasource.DataSource {
+	return &DataplanePoolsDataSource{}
+}
+
+// DataplanePoolsDataSource defines the data source implementation.
+type DataplanePoolsDataSource struct {
+	conn cluster.ClusterServiceClient
+	org  string
+}
+
+// DataplanePoolsDataSourceModel describes the data source data model.
+type DataplanePoolsDataSourceModel struct {
+	Dataplane types.String `tfsdk:"dataplane"`
+	Pools     types.Set    `tfsdk:"pools"`
+}
+
+func (d *DataplanePoolsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataplane_pools"
+}
+
+func (d *DataplanePoolsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Dataplane pools data source",
+
+		Attributes: map[string]schema.Attribute{
+			"dataplane": schema.StringAttribute{
+				MarkdownDescription: "Dataplane identifier",
+				Required:            true,
+			},
+			"pools": schema.SetAttribute{
+				MarkdownDescription: "Names of the cluster pools the dataplane belongs to",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *DataplanePoolsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.conn = cluster.NewClusterServiceClient(client.conn)
+	if d.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cluster.ClusterServiceClient, got: %T. Please report this issue to the provider developers.", d.conn),
+		)
+		return
+	}
+	d.org = client.org
+}
+
+func (d *DataplanePoolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data DataplanePoolsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	c, err := d.conn.GetCluster(ctx, &cluster.GetRequest{
+		ClusterId: &common.ClusterIdentifier{
+			Name:         data.Dataplane.ValueString(),
+			Organization: d.org,
+		},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.Diagnostics.AddError("Dataplane not found", fmt.Sprintf("Dataplane with ID %s not found", data.Dataplane.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Failed to fetch dataplane", err.Error())
+		return
+	}
+
+	data.Pools = convertArrayToSetGetter(c.GetCluster().GetPools(), func(pool *common.ClusterPoolIdentifier) string {
+		return pool.GetName()
+	})
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
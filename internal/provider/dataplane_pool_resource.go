@@ -1 +1,5 @@
 package provider
+
+// Dataplanes report the cluster pools they belong to but the API offers no way
+// to change that membership, so it is only exposed through the
+// unionai_dataplane_pools data source.
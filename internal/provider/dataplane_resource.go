@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &DataplaneResource{}
+var _ resource.ResourceWithImportState = &DataplaneResource{}
+
+func NewDataplaneResource() resource.Resource {
+	return &DataplaneResource{}
+}
+
+// DataplaneResource adopts a dataplane that registered itself with the
+// ClusterService. Dataplanes cannot be created through the API, only adopted
+// and deregistered.
+type DataplaneResource struct {
+	conn cluster.ClusterServiceClient
+	org  string
+}
+
+// DataplaneResourceModel describes the resource data model.
+type DataplaneResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Enabled            types.Bool   `tfsdk:"enabled"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+	AssignedConfigId   types.String `tfsdk:"assigned_config_id"`
+	Health             types.String `tfsdk:"health"`
+}
+
+func (r *DataplaneResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dataplane"
+}
+
+func (r *DataplaneResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Dataplane resource. Adopts a dataplane that has registered with Union.ai so it can be deregistered. Enabling or disabling a dataplane, for example to drain it, and assigning a config are not supported.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Dataplane identifier, the same as its name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the registered dataplane.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"enabled": schema.BoolAttribute{
+				Computed:            true,
+				MarkdownDescription: "Whether the dataplane accepts new workloads. Read-only: the resource cannot enable or disable the dataplane, so it cannot be drained through Terraform.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether destroying the resource is refused. Set to `false` to deregister the dataplane on destroy. Defaults to `true`.",
+			},
+			"assigned_config_id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Identifier of the config assigned to the dataplane. Read-only: the resource cannot assign a config.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"health": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Dataplane health.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+func (r *DataplaneResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = cluster.NewClusterServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cluster.ClusterServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func (r *DataplaneResource) clusterId(data *DataplaneResourceModel) *common.ClusterIdentifier {
+	return &common.ClusterIdentifier{
+		Name:         data.Name.ValueString(),
+		Organization: r.org,
+	}
+}
+
+// refresh copies the remote dataplane into the model.
+func (r *DataplaneResource) refresh(data *DataplaneResourceModel, c *cluster.Cluster) {
+	data.Id = types.StringValue(c.GetSpec().GetId().GetName())
+	data.Name = types.StringValue(c.GetSpec().GetId().GetName())
+	data.Enabled = types.BoolValue(dataplaneState(c) != cluster.State_STATE_DISABLED)
+	data.AssignedConfigId = optionalString(c.GetSpec().GetAssignedConfigId())
+	data.Health = types.StringValue(cluster.Status_Health_name[int32(c.GetStatus().GetHealth())])
+}
+
+// dataplaneState returns the desired state of the dataplane from its spec,
+// falling back to the state reported by the operator.
+func dataplaneState(c *cluster.Cluster) cluster.State {
+	if state := c.GetSpec().GetState(); state != cluster.State_STATE_UNSPECIFIED {
+		return state
+	}
+	return c.GetStatus().GetState()
+}
+
+func (r *DataplaneResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data DataplaneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.GetCluster(ctx, &cluster.GetRequest{ClusterId: r.clusterId(&data)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.Diagnostics.AddError("Dataplane not found", fmt.Sprintf("Dataplane %s has not registered. Dataplanes register themselves once the Union operator is installed, they cannot be created here.", data.Name.ValueString()))
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataplane %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	r.refresh(&data, got.GetCluster())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DataplaneResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data DataplaneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.GetCluster(ctx, &cluster.GetRequest{ClusterId: r.clusterId(&data)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataplane %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	r.refresh(&data, got.GetCluster())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DataplaneResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data DataplaneResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.GetCluster(ctx, &cluster.GetRequest{ClusterId: r.clusterId(&data)})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read dataplane %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	r.refresh(&data, got.GetCluster())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *DataplaneResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data DataplaneResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.DeletionProtection.ValueBool() {
+		resp.Diagnostics.AddError(
+			"Dataplane Is Protected",
+			fmt.Sprintf("Dataplane %s has deletion_protection enabled. Set deletion_protection to false and apply before destroying it to deregister the dataplane.", data.Name.ValueString()),
+		)
+		return
+	}
+
+	_, err := r.conn.DeleteCluster(ctx, &cluster.DeleteRequest{ClusterId: r.clusterId(&data)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete dataplane %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+func (r *DataplaneResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("deletion_protection"), true)...)
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+)
+
+func TestDataplaneResource_Refresh(t *testing.T) {
+	id := &common.ClusterIdentifier{Name: "dp-1", Organization: "test-org"}
+	r := &DataplaneResource{org: "test-org"}
+
+	// The operator reports its own state, which may lag behind the spec
+	var data DataplaneResourceModel
+	r.refresh(&data, &cluster.Cluster{
+		Spec:   &cluster.Spec{Id: id, State: cluster.State_STATE_DISABLED, AssignedConfigId: "cfg-1"},
+		Status: &cluster.Status{Health: cluster.Status_HEALTHY, State: cluster.State_STATE_ENABLED},
+	})
+	if data.Id.ValueString() != "dp-1" || data.Enabled.ValueBool() || data.AssignedConfigId.ValueString() != "cfg-1" || data.Health.ValueString() != "HEALTHY" {
+		t.Errorf("Unexpected refreshed dataplane: %+v", data)
+	}
+
+	r.refresh(&data, &cluster.Cluster{
+		Spec:   &cluster.Spec{Id: id},
+		Status: &cluster.Status{State: cluster.State_STATE_DISABLED},
+	})
+	if data.Enabled.ValueBool() {
+		t.Error("Expected the reported state to be used when the spec has none")
+	}
+
+	r.refresh(&data, &cluster.Cluster{Spec: &cluster.Spec{Id: id}})
+	if !data.Enabled.ValueBool() {
+		t.Error("Expected a dataplane without state to be enabled")
+	}
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultDataplaneWaitTimeout bounds how long a lookup waits for a dataplane
+// to become healthy.
+const defaultDataplaneWaitTimeout = 20 * time.Minute
+
+// dataplanePollInterval is how often a dataplane is polled while waiting for
+// it to become healthy.
+var dataplanePollInterval = 15 * time.Second
+
+// dataplaneReady reports whether a dataplane is healthy and enabled.
+func dataplaneReady(c *cluster.Cluster) bool {
+	return c.GetStatus().GetHealth() == cluster.Status_HEALTHY && c.GetStatus().GetState() == cluster.State_STATE_ENABLED
+}
+
+// waitForHealthyDataplane polls a dataplane until it is healthy and enabled or
+// the timeout expires, and returns it. A dataplane that is still being
+// bootstrapped has not registered yet, so not found is treated as not ready.
+// On timeout the error carries the last reported health, state and unhealthy
+// reasons.
+func waitForHealthyDataplane(ctx context.Context, conn cluster.ClusterServiceClient, org string, name string, timeout time.Duration) (*cluster.Cluster, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var last *cluster.Cluster
+	for {
+		got, err := conn.GetCluster(ctx, &cluster.GetRequest{
+			ClusterId: &common.ClusterIdentifier{
+				Name:         name,
+				Organization: org,
+			},
+		})
+		switch {
+		case err == nil:
+			last = got.GetCluster()
+			if dataplaneReady(last) {
+				return last, nil
+			}
+		case ctx.Err() == nil && status.Code(err) != codes.NotFound:
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, dataplaneTimeoutError(name, timeout, last)
+		case <-time.After(dataplanePollInterval):
+		}
+	}
+}
+
+func dataplaneTimeoutError(name string, timeout time.Duration, last *cluster.Cluster) error {
+	if last == nil {
+		return fmt.Errorf("dataplane %s did not register within %s", name, timeout)
+	}
+	msg := fmt.Sprintf("dataplane %s did not become healthy within %s (health %s, state %s)",
+		name, timeout, cluster.Status_Health_name[int32(last.GetStatus().GetHealth())], cluster.State_name[int32(last.GetStatus().GetState())])
+	if reasons := last.GetStatus().GetUnhealthyReasons(); len(reasons) > 0 {
+		msg += ": " + strings.Join(reasons, "; ")
+	}
+	return fmt.Errorf("%s", msg)
+}
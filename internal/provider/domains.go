@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/service"
+)
+
+// defaultDomains are the domains every organization starts with. They are
+// used for validation when the domains of the organization cannot be read.
+var defaultDomains = []string{
+	"production",
+	"staging",
+	"development",
+}
+
+// fetchDomains reads the domains configured on the control plane.
+func fetchDomains(ctx context.Context, conn service.AdminServiceClient) ([]*admin.Domain, error) {
+	got, err := conn.GetDomains(ctx, &admin.GetDomainRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return got.GetDomains(), nil
+}
+
+// domainIds returns the identifiers of the domains, falling back to the
+// default domains when none were read.
+func domainIds(domains []*admin.Domain) []string {
+	if len(domains) == 0 {
+		return defaultDomains
+	}
+	ids := make([]string, len(domains))
+	for i, d := range domains {
+		ids[i] = d.GetId()
+	}
+	return ids
+}
+
+// validateDomain checks that domain is one of the domains of the organization.
+func validateDomain(domains []string, domain string) error {
+	if !slices.Contains(domains, domain) {
+		return fmt.Errorf("domain %s is not valid. Must be one of %v", domain, domains)
+	}
+	return nil
+}
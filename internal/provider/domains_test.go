@@ -0,0 +1,21 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/admin"
+)
+
+func TestDomainIds(t *testing.T) {
+	ids := domainIds([]*admin.Domain{{Id: "development", Name: "Development"}, {Id: "qa", Name: "QA"}})
+	if err := validateDomain(ids, "qa"); err != nil {
+		t.Errorf("Expected a custom domain to be valid, got %s", err)
+	}
+	if err := validateDomain(ids, "production"); err == nil {
+		t.Error("Expected a domain missing from the organization to be rejected")
+	}
+
+	if err := validateDomain(domainIds(nil), "production"); err != nil {
+		t.Errorf("Expected the default domains when none were read, got %s", err)
+	}
+}
@@ -1,13 +1,19 @@
 package provider
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"sync"
 	"sync/atomic"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
@@ -15,6 +21,123 @@ import (
 type FlyteEnvironment struct {
 	numRuns int32
 	lock    sync.Mutex
+	// jsonOutput caches, per CLI path, environment and working directory,
+	// whether --output-format json is supported
+	jsonOutput map[string]bool
+}
+
+// defaultFlyteCLITimeout bounds a single flyte CLI invocation when no
+// timeout is configured.
+const defaultFlyteCLITimeout = 10 * time.Minute
+
+// flyteUsageExitCode is the exit code of the CLI for usage errors, such as an
+// unknown option.
+const flyteUsageExitCode = 2
+
+// FlyteCLIConfig controls how the flyte CLI is run.
+type FlyteCLIConfig struct {
+	// Path of the flyte binary, looked up in PATH when it has no separator.
+	Path string
+	// Env holds extra environment variables, added to the provider's own.
+	Env map[string]string
+	// WorkingDir is the directory the CLI runs in. Relative task paths are
+	// resolved against it.
+	WorkingDir string
+	// Timeout bounds each invocation of the CLI.
+	Timeout time.Duration
+	// ApiKey is passed to the CLI so it authenticates as the provider.
+	ApiKey string
+}
+
+// cacheKey identifies the CLI installation a configuration runs: the same
+// binary path may resolve to a different virtualenv under another
+// environment or working directory.
+func (c FlyteCLIConfig) cacheKey() string {
+	keys := make([]string, 0, len(c.Env))
+	for key := range c.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\x00%s", c.Path, c.WorkingDir)
+	for _, key := range keys {
+		fmt.Fprintf(&b, "\x00%s=%s", key, c.Env[key])
+	}
+	return b.String()
+}
+
+// withOverrides returns a copy of the configuration with the set fields of
+// the model applied on top. Environment variables are merged.
+func (c FlyteCLIConfig) withOverrides(model *FlyteCLIModel) (FlyteCLIConfig, error) {
+	if model == nil {
+		return c, nil
+	}
+
+	if !model.Path.IsNull() && !model.Path.IsUnknown() {
+		c.Path = model.Path.ValueString()
+	}
+	if !model.WorkingDir.IsNull() && !model.WorkingDir.IsUnknown() {
+		c.WorkingDir = model.WorkingDir.ValueString()
+	}
+	if !model.Timeout.IsNull() && !model.Timeout.IsUnknown() {
+		timeout, err := time.ParseDuration(model.Timeout.ValueString())
+		if err != nil {
+			return c, fmt.Errorf("invalid flyte CLI timeout %q: %w", model.Timeout.ValueString(), err)
+		}
+		c.Timeout = timeout
+	}
+	if !model.Env.IsNull() && !model.Env.IsUnknown() {
+		env := make(map[string]string, len(c.Env)+len(model.Env.Elements()))
+		for key, value := range c.Env {
+			env[key] = value
+		}
+		for key, value := range convertMapToStrings(model.Env) {
+			env[key] = value
+		}
+		c.Env = env
+	}
+	return c, nil
+}
+
+// resolve returns the path relative to the working directory of the CLI.
+func (c FlyteCLIConfig) resolve(path string) string {
+	if c.WorkingDir == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(c.WorkingDir, path)
+}
+
+// command builds the CLI command. The environment is the provider's own,
+// plus the configured variables, plus the provider API key.
+func (c FlyteCLIConfig) command(ctx context.Context, args ...string) *exec.Cmd {
+	path := c.Path
+	if path == "" {
+		path = "flyte"
+	}
+
+	cmd := exec.CommandContext(ctx, path, args...)
+	cmd.Dir = c.WorkingDir
+
+	keys := make([]string, 0, len(c.Env))
+	for key := range c.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	cmd.Env = os.Environ()
+	for _, key := range keys {
+		cmd.Env = append(cmd.Env, key+"="+c.Env[key])
+	}
+	if c.ApiKey != "" {
+		cmd.Env = append(cmd.Env, "FLYTE_API_KEY="+c.ApiKey)
+	}
+
+	// Give the CLI a moment to exit cleanly on cancellation before it is killed
+	cmd.Cancel = func() error {
+		return cmd.Process.Signal(os.Interrupt)
+	}
+	cmd.WaitDelay = 10 * time.Second
+	return cmd
 }
 
 type FlyteEnvironmentDetails struct {
@@ -23,6 +146,72 @@ type FlyteEnvironmentDetails struct {
 	Tasks   []string
 }
 
+// FlyteCLIMissingError is returned when the flyte CLI cannot be found.
+type FlyteCLIMissingError struct {
+	Path string
+	Err  error
+}
+
+func (e *FlyteCLIMissingError) Error() string {
+	return fmt.Sprintf("flyte CLI %q not found, install it with `pip install flyte`: %s", e.Path, e.Err)
+}
+
+func (e *FlyteCLIMissingError) Unwrap() error {
+	return e.Err
+}
+
+// FlyteCLIWorkingDirError is returned when the working directory of the flyte
+// CLI does not exist or is not a directory.
+type FlyteCLIWorkingDirError struct {
+	Dir string
+	Err error
+}
+
+func (e *FlyteCLIWorkingDirError) Error() string {
+	return fmt.Sprintf("invalid flyte CLI working directory %q: %s", e.Dir, e.Err)
+}
+
+func (e *FlyteCLIWorkingDirError) Unwrap() error {
+	return e.Err
+}
+
+// FlyteCLIExitError is returned when the flyte CLI exits with an error. The
+// standard error of the command is attached.
+type FlyteCLIExitError struct {
+	Args     []string
+	ExitCode int
+	Stderr   string
+	Err      error
+}
+
+func (e *FlyteCLIExitError) Error() string {
+	var msg string
+	if e.ExitCode < 0 {
+		// Killed by a signal, a timeout or a cancellation
+		msg = fmt.Sprintf("flyte %s failed: %s", strings.Join(e.Args, " "), e.Err)
+	} else {
+		msg = fmt.Sprintf("flyte %s failed with exit code %d", strings.Join(e.Args, " "), e.ExitCode)
+	}
+	if e.Stderr != "" {
+		msg += ":\n" + e.Stderr
+	}
+	return msg
+}
+
+func (e *FlyteCLIExitError) Unwrap() error {
+	return e.Err
+}
+
+// FlyteOutputParseError is returned when the environment name and version
+// cannot be found in the output of the flyte CLI.
+type FlyteOutputParseError struct {
+	Output string
+}
+
+func (e *FlyteOutputParseError) Error() string {
+	return fmt.Sprintf("failed to parse name and version from flyte deploy output:\n%s", e.Output)
+}
+
 // Generic remove ANSI codes from a byte slice
 func (f *FlyteEnvironment) removeAnsiCodes(data []byte) []byte {
 	// ANSI escape sequence pattern: \x1b[...m
@@ -131,8 +320,119 @@ func (f *FlyteEnvironment) fetchTable(fields []string, input []byte) [][]string
 	return results
 }
 
+// runFlyte runs the flyte CLI and returns its standard output. The command
+// is killed when the context is cancelled or the timeout expires. Failures
+// are reported as *FlyteCLIWorkingDirError, *FlyteCLIMissingError or
+// *FlyteCLIExitError.
+func (f *FlyteEnvironment) runFlyte(ctx context.Context, cli FlyteCLIConfig, args ...string) ([]byte, error) {
+	timeout := cli.Timeout
+	if timeout <= 0 {
+		timeout = defaultFlyteCLITimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := cli.command(ctx, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// A missing working directory and a missing binary fail the same way once
+	// the command is started, so check both beforehand
+	if cmd.Dir != "" {
+		info, err := os.Stat(cmd.Dir)
+		if err == nil && !info.IsDir() {
+			err = errors.New("not a directory")
+		}
+		if err != nil {
+			return nil, &FlyteCLIWorkingDirError{Dir: cmd.Dir, Err: err}
+		}
+	}
+	if strings.ContainsRune(cmd.Path, filepath.Separator) {
+		binary := cmd.Path
+		if !filepath.IsAbs(binary) && cmd.Dir != "" {
+			binary = filepath.Join(cmd.Dir, binary)
+		}
+		if _, err := os.Stat(binary); errors.Is(err, os.ErrNotExist) {
+			return nil, &FlyteCLIMissingError{Path: cmd.Path, Err: err}
+		}
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return nil, &FlyteCLIMissingError{Path: cmd.Path, Err: err}
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			if errors.Is(ctxErr, context.DeadlineExceeded) {
+				ctxErr = fmt.Errorf("timed out after %s: %w", timeout, ctxErr)
+			}
+			err = fmt.Errorf("%w: %w", ctxErr, err)
+		}
+		exitCode := -1
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			exitCode = exitErr.ExitCode()
+		}
+		return nil, &FlyteCLIExitError{
+			Args:     args,
+			ExitCode: exitCode,
+			Stderr:   strings.TrimSpace(string(f.removeAnsiCodes(stderr.Bytes()))),
+			Err:      err,
+		}
+	}
+	return f.removeAnsiCodes(out), nil
+}
+
+// parseDeployTable extracts the environment names and entities from the
+// table printed by older CLI versions that cannot emit JSON.
+func (f *FlyteEnvironment) parseDeployTable(out []byte) ([]string, []flyteEntity) {
+	var environments []string
+	for _, row := range f.fetchTable([]string{"Environment", "Image"}, out) {
+		environments = append(environments, row[0])
+	}
+
+	var entities []flyteEntity
+	for _, row := range f.fetchTable([]string{"Type", "Name", "Version"}, out) {
+		if len(row) >= 3 {
+			entities = append(entities, flyteEntity{Type: row[0], Name: row[1], Version: row[2]})
+		}
+	}
+	return environments, entities
+}
+
+// supportsJSONOutput reports whether the CLI accepts --output-format json.
+// CLI versions without the option exit with a usage error. The result is
+// cached per CLI installation, see cacheKey.
+func (f *FlyteEnvironment) supportsJSONOutput(ctx context.Context, cli FlyteCLIConfig) (bool, error) {
+	f.lock.Lock()
+	supported, ok := f.jsonOutput[cli.cacheKey()]
+	f.lock.Unlock()
+	if ok {
+		return supported, nil
+	}
+
+	_, err := f.runFlyte(ctx, cli, "--output-format", "json", "--help")
+	var exitErr *FlyteCLIExitError
+	switch {
+	case err == nil:
+		supported = true
+	case errors.As(err, &exitErr) && exitErr.ExitCode == flyteUsageExitCode:
+		supported = false
+	default:
+		return false, err
+	}
+
+	f.lock.Lock()
+	if f.jsonOutput == nil {
+		f.jsonOutput = map[string]bool{}
+	}
+	f.jsonOutput[cli.cacheKey()] = supported
+	f.lock.Unlock()
+	return supported, nil
+}
+
 func (f *FlyteEnvironment) retrieveNameAndVersion(
-	ctx context.Context,
+	ctx context.Context, cli FlyteCLIConfig,
 	path string, project string, domain string, id string,
 ) (*FlyteEnvironmentDetails, error) {
 	// TODO(nelson): Fix flyte deploy bug, where first time calculates the wrong hash.
@@ -141,54 +441,59 @@ func (f *FlyteEnvironment) retrieveNameAndVersion(
 	if atomic.AddInt32(&f.numRuns, 1) == 1 {
 		// First run is buggy, so let's waste it.
 		f.lock.Unlock()
-		if _, err := f.retrieveNameAndVersion(ctx, path, project, domain, id); err != nil {
+		if _, err := f.retrieveNameAndVersion(ctx, cli, path, project, domain, id); err != nil {
 			return nil, err
 		}
 	} else {
 		f.lock.Unlock()
 	}
 
-	cmd := exec.Command("flyte", "deploy", "--dry-run",
+	args := []string{"deploy", "--dry-run",
 		"--project", project,
 		"--domain", domain,
 		path, id,
-	)
-	out, err := cmd.CombinedOutput()
+	}
+
+	// Ask for JSON, and fall back to the table for CLI versions without it
+	jsonOutput, err := f.supportsJSONOutput(ctx, cli)
 	if err != nil {
-		return nil, fmt.Errorf("flyte deploy failed: %w", err)
+		return nil, err
+	}
+	if jsonOutput {
+		args = append([]string{"--output-format", "json"}, args...)
+	}
+	out, err := f.runFlyte(ctx, cli, args...)
+	if err != nil {
+		return nil, err
 	}
-	out = f.removeAnsiCodes(out)
 
-	var name string
+	var environments []string
+	var entities []flyteEntity
+	parsed := false
+	if jsonOutput {
+		environments, entities, parsed = parseDeployJSON(out)
+	}
+	if !parsed {
+		environments, entities = f.parseDeployTable(out)
+	}
+	if len(environments) == 0 {
+		return nil, &FlyteOutputParseError{Output: string(out)}
+	}
+
+	name := environments[0]
 	var version string
 	var tasks []string
 
-	// Parse environment table to get the environment name
-	envTable := f.fetchTable([]string{"Environment", "Image"}, out)
-	if len(envTable) > 0 {
-		name = envTable[0][0]
-	}
-
-	// Parse entities table to get version and tasks
-	entitiesTable := f.fetchTable([]string{"Type", "Name", "Version"}, out)
-	for _, row := range entitiesTable {
-		if len(row) >= 3 && row[0] == "task" {
-			taskName := row[1]
-			taskVersion := row[2]
-			// Only include tasks that belong to this environment
-			if strings.HasPrefix(taskName, name+".") {
-				if version == "" {
-					version = taskVersion
-				}
-				tasks = append(tasks, taskName)
+	for _, entity := range entities {
+		// Only include tasks that belong to this environment
+		if entity.Type == "task" && strings.HasPrefix(entity.Name, name+".") {
+			if version == "" {
+				version = entity.Version
 			}
+			tasks = append(tasks, entity.Name)
 		}
 	}
 
-	if name == "" || version == "" {
-		return nil, fmt.Errorf("failed to parse name and version from flyte deploy output")
-	}
-
 	tflog.Trace(ctx, "retrieveNameAndVersion", map[string]interface{}{
 		"name":    name,
 		"version": version,
@@ -196,6 +501,10 @@ func (f *FlyteEnvironment) retrieveNameAndVersion(
 		"output":  string(out),
 	})
 
+	if name == "" || version == "" {
+		return nil, &FlyteOutputParseError{Output: string(out)}
+	}
+
 	return &FlyteEnvironmentDetails{
 		Name:    name,
 		Version: version,
@@ -203,14 +512,11 @@ func (f *FlyteEnvironment) retrieveNameAndVersion(
 	}, nil
 }
 
-func (f *FlyteEnvironment) uploadNewVersion(path string, project string, domain string, id string) error {
-	cmd := exec.Command("flyte", "deploy",
+func (f *FlyteEnvironment) uploadNewVersion(ctx context.Context, cli FlyteCLIConfig, path string, project string, domain string, id string) error {
+	_, err := f.runFlyte(ctx, cli, "deploy",
 		"--project", project,
 		"--domain", domain,
 		path, id,
 	)
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("flyte deploy failed: %w", err)
-	}
-	return nil
+	return err
 }
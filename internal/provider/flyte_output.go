@@ -0,0 +1,60 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// flyteEntity is a deployable entity listed by `flyte deploy --dry-run`.
+type flyteEntity struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	Environment string `json:"environment,omitempty"`
+}
+
+// flyteDeployOutput is the JSON document printed by
+// `flyte --output-format json deploy --dry-run`.
+type flyteDeployOutput struct {
+	Environments []struct {
+		Name string `json:"name"`
+	} `json:"environments"`
+	Entities []flyteEntity `json:"entities"`
+}
+
+// parseDeployJSON extracts the environment names and entities from the JSON
+// output of `flyte --output-format json deploy --dry-run`. The output is
+// either a document with environments and entities, or a list of entities
+// that each name their environment. Any log lines printed before the JSON
+// document are skipped. ok is false when no JSON document or no entity could
+// be found.
+func parseDeployJSON(out []byte) (environments []string, entities []flyteEntity, ok bool) {
+	start := bytes.IndexAny(out, "[{")
+	if start < 0 {
+		return nil, nil, false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(out[start:]))
+
+	if out[start] == '[' {
+		if err := decoder.Decode(&entities); err != nil {
+			return nil, nil, false
+		}
+		for _, entity := range entities {
+			if entity.Environment != "" {
+				environments = append(environments, entity.Environment)
+			}
+		}
+		return environments, entities, len(entities) > 0
+	}
+
+	var doc flyteDeployOutput
+	if err := decoder.Decode(&doc); err != nil {
+		return nil, nil, false
+	}
+	for _, env := range doc.Environments {
+		if env.Name != "" {
+			environments = append(environments, env.Name)
+		}
+	}
+	return environments, doc.Entities, len(doc.Entities) > 0
+}
@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestParseDeployJSON(t *testing.T) {
+	out := []byte(`Loading hello.py...
+{
+  "environments": [{"name": "hello_world", "image": "auto"}],
+  "entities": [
+    {"type": "task", "name": "hello_world.fn", "version": "d8b4e239"},
+    {"type": "task", "name": "hello_world.main", "version": "d8b4e239", "triggers": ""}
+  ]
+}`)
+
+	environments, entities, ok := parseDeployJSON(out)
+	if !ok {
+		t.Fatal("Expected JSON output to be parsed")
+	}
+	if len(environments) != 1 || environments[0] != "hello_world" {
+		t.Errorf("Expected environment hello_world, got %v", environments)
+	}
+	if len(entities) != 2 || entities[1].Name != "hello_world.main" || entities[1].Version != "d8b4e239" {
+		t.Errorf("Expected two task entities, got %v", entities)
+	}
+
+	// A flat list of entities, each naming its environment
+	environments, entities, ok = parseDeployJSON([]byte(`[{"type": "task", "name": "env.main", "version": "v1", "environment": "env"}]`))
+	if !ok || len(entities) != 1 || entities[0].Type != "task" || len(environments) != 1 || environments[0] != "env" {
+		t.Errorf("Expected one entity from a list of rows, got %v and %v", environments, entities)
+	}
+
+	if _, _, ok := parseDeployJSON([]byte("┌───┐\n│ Type │\n")); ok {
+		t.Error("Expected table output to be rejected")
+	}
+}
+
+func TestParseDeployTable(t *testing.T) {
+	f := &FlyteEnvironment{}
+	out := []byte(`┌──────────────┬───────┐
+│ Environment  │ Image │
+╞══════════════╪═══════╡
+│ hello_world  │ auto  │
+└──────────────┴───────┘
+┌──────┬──────────────────┬──────────┐
+│ Type │ Name             │ Version  │
+╞══════╪══════════════════╪══════════╡
+│ task │ hello_world.main │ d8b4e239 │
+└──────┴──────────────────┴──────────┘`)
+
+	environments, entities := f.parseDeployTable(out)
+	if len(environments) != 1 || environments[0] != "hello_world" {
+		t.Errorf("Expected environment hello_world, got %v", environments)
+	}
+	if len(entities) != 1 || entities[0].Version != "d8b4e239" {
+		t.Errorf("Expected one task entity, got %v", entities)
+	}
+}
+
+func TestRunFlyte_Missing(t *testing.T) {
+	t.Setenv("PATH", t.TempDir())
+
+	f := &FlyteEnvironment{}
+	_, err := f.runFlyte(context.Background(), FlyteCLIConfig{}, "--version")
+
+	var missing *FlyteCLIMissingError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Expected a FlyteCLIMissingError, got %T: %v", err, err)
+	}
+}
+
+func TestRunFlyte_WorkingDir(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "flyte")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("Failed to write script: %s", err)
+	}
+
+	f := &FlyteEnvironment{}
+	_, err := f.runFlyte(context.Background(), FlyteCLIConfig{Path: script, WorkingDir: filepath.Join(dir, "missing")})
+	var workingDir *FlyteCLIWorkingDirError
+	if !errors.As(err, &workingDir) {
+		t.Fatalf("Expected a FlyteCLIWorkingDirError, got %T: %v", err, err)
+	}
+
+	_, err = f.runFlyte(context.Background(), FlyteCLIConfig{Path: script, WorkingDir: script})
+	if !errors.As(err, &workingDir) {
+		t.Fatalf("Expected a FlyteCLIWorkingDirError for a file, got %T: %v", err, err)
+	}
+
+	_, err = f.runFlyte(context.Background(), FlyteCLIConfig{Path: filepath.Join(dir, "missing", "flyte")})
+	var missing *FlyteCLIMissingError
+	if !errors.As(err, &missing) {
+		t.Fatalf("Expected a FlyteCLIMissingError, got %T: %v", err, err)
+	}
+}
+
+func TestRetrieveNameAndVersion_OutputFormat(t *testing.T) {
+	dir := t.TempDir()
+	writeScript := func(name, body string) string {
+		script := filepath.Join(dir, name)
+		if err := os.WriteFile(script, []byte("#!/bin/sh\n"+body), 0o755); err != nil {
+			t.Fatalf("Failed to write script: %s", err)
+		}
+		return script
+	}
+
+	jsonCLI := writeScript("flyte-json", `[ "$1" = --output-format ] || exit 3
+[ "$3" = --help ] && exit 0
+echo '{"environments": [{"name": "env"}], "entities": [{"type": "task", "name": "env.main", "version": "v1"}]}'
+`)
+	tableCLI := writeScript("flyte-table", `[ "$1" = --output-format ] && { echo "No such option: --output-format" >&2; exit 2; }
+cat <<'EOT'
+│ Environment │ Image │
+╞═════════════╪═══════╡
+│ env         │ auto  │
+└─────────────┴───────┘
+│ Type │ Name     │ Version │
+╞══════╪══════════╪═════════╡
+│ task │ env.main │ v2      │
+└──────┴──────────┴─────────┘
+EOT
+`)
+
+	// Claims JSON support but still prints the table
+	mixedCLI := writeScript("flyte-mixed", `[ "$3" = --help ] && exit 0
+cat <<'EOT'
+│ Environment │ Image │
+╞═════════════╪═══════╡
+│ env         │ auto  │
+└─────────────┴───────┘
+│ Type │ Name     │ Version │
+╞══════╪══════════╪═════════╡
+│ task │ env.main │ v3      │
+└──────┴──────────┴─────────┘
+EOT
+`)
+	garbageCLI := writeScript("flyte-garbage", `[ "$3" = --help ] && exit 0
+echo "Deployed nothing"
+`)
+
+	f := &FlyteEnvironment{}
+	for cli, version := range map[string]string{jsonCLI: "v1", tableCLI: "v2", mixedCLI: "v3"} {
+		details, err := f.retrieveNameAndVersion(context.Background(), FlyteCLIConfig{Path: cli}, "hello.py", "p", "d", "env")
+		if err != nil {
+			t.Fatalf("retrieveNameAndVersion() with %s returned error: %s", filepath.Base(cli), err)
+		}
+		if details.Name != "env" || details.Version != version || len(details.Tasks) != 1 {
+			t.Errorf("Unexpected details with %s: %+v", filepath.Base(cli), details)
+		}
+	}
+	if !f.jsonOutput[FlyteCLIConfig{Path: jsonCLI}.cacheKey()] || f.jsonOutput[FlyteCLIConfig{Path: tableCLI}.cacheKey()] {
+		t.Errorf("Unexpected output format detection: %v", f.jsonOutput)
+	}
+
+	// The same binary supports JSON output only in some environments
+	envCLI := writeScript("flyte-env", `[ "$1" = --output-format ] && [ -z "$FLYTE_JSON" ] && exit 2
+exit 0
+`)
+	for env, expected := range map[string]bool{"": false, "1": true} {
+		cli := FlyteCLIConfig{Path: envCLI, Env: map[string]string{"FLYTE_JSON": env}}
+		supported, err := f.supportsJSONOutput(context.Background(), cli)
+		if err != nil {
+			t.Fatalf("supportsJSONOutput() returned error: %s", err)
+		}
+		if supported != expected {
+			t.Errorf("Expected JSON support %t with FLYTE_JSON=%q, got %t", expected, env, supported)
+		}
+	}
+
+	_, err := f.retrieveNameAndVersion(context.Background(), FlyteCLIConfig{Path: garbageCLI}, "hello.py", "p", "d", "env")
+	var parseErr *FlyteOutputParseError
+	if !errors.As(err, &parseErr) {
+		t.Errorf("Expected a FlyteOutputParseError, got %T: %v", err, err)
+	}
+}
+
+func TestFlyteCLIConfig_WithOverrides(t *testing.T) {
+	base := FlyteCLIConfig{
+		Path:    "/opt/venv/bin/flyte",
+		Env:     map[string]string{"A": "1", "B": "2"},
+		Timeout: time.Minute,
+		ApiKey:  "key",
+	}
+
+	cli, err := base.withOverrides(&FlyteCLIModel{
+		Path:       types.StringNull(),
+		Env:        types.MapValueMust(types.StringType, map[string]attr.Value{"B": types.StringValue("3")}),
+		WorkingDir: types.StringValue("/src"),
+		Timeout:    types.StringValue("90s"),
+	})
+	if err != nil {
+		t.Fatalf("withOverrides() returned error: %s", err)
+	}
+	if cli.Path != "/opt/venv/bin/flyte" || cli.WorkingDir != "/src" || cli.Timeout != 90*time.Second {
+		t.Errorf("Unexpected overrides: %+v", cli)
+	}
+	if cli.Env["A"] != "1" || cli.Env["B"] != "3" || base.Env["B"] != "2" {
+		t.Errorf("Expected env to be merged without changing the base, got %v and %v", cli.Env, base.Env)
+	}
+	if cli.resolve("hello.py") != "/src/hello.py" || cli.resolve("/abs/hello.py") != "/abs/hello.py" {
+		t.Errorf("Unexpected path resolution against %s", cli.WorkingDir)
+	}
+
+	if _, err := base.withOverrides(&FlyteCLIModel{
+		Path:       types.StringNull(),
+		Env:        types.MapNull(types.StringType),
+		WorkingDir: types.StringNull(),
+		Timeout:    types.StringValue("soon"),
+	}); err == nil {
+		t.Error("Expected an invalid timeout to be rejected")
+	}
+}
+
+func TestRunFlyte_EnvAndTimeout(t *testing.T) {
+	dir := t.TempDir()
+	script := filepath.Join(dir, "flyte")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho \"$FLYTE_API_KEY $EXTRA\"\n[ \"$1\" = sleep ] && exec sleep 5\nexit 0\n"), 0o755); err != nil {
+		t.Fatalf("Failed to write script: %s", err)
+	}
+
+	f := &FlyteEnvironment{}
+	cli := FlyteCLIConfig{
+		Path:   script,
+		Env:    map[string]string{"EXTRA": "x", "FLYTE_API_KEY": "ignored"},
+		ApiKey: "provider-key",
+	}
+	out, err := f.runFlyte(context.Background(), cli)
+	if err != nil {
+		t.Fatalf("runFlyte() returned error: %s", err)
+	}
+	if got := strings.TrimSpace(string(out)); got != "provider-key x" {
+		t.Errorf("Expected the provider API key and extra env, got %q", got)
+	}
+
+	cli.Timeout = 100 * time.Millisecond
+	start := time.Now()
+	_, err = f.runFlyte(context.Background(), cli, "sleep")
+	var exitErr *FlyteCLIExitError
+	if !errors.As(err, &exitErr) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected a timed out FlyteCLIExitError, got %T: %v", err, err)
+	}
+	if time.Since(start) > 4*time.Second {
+		t.Errorf("Expected the command to be killed on timeout, took %s", time.Since(start))
+	}
+}
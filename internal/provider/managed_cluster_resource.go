@@ -0,0 +1,593 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ManagedClusterResource{}
+var _ resource.ResourceWithImportState = &ManagedClusterResource{}
+var _ resource.ResourceWithValidateConfig = &ManagedClusterResource{}
+
+const (
+	// defaultManagedClusterCreateTimeout bounds how long create waits for the
+	// dataplane of a new managed cluster to become healthy.
+	defaultManagedClusterCreateTimeout = 60 * time.Minute
+	// defaultManagedClusterDeleteTimeout bounds how long delete waits for the
+	// managed cluster to be torn down.
+	defaultManagedClusterDeleteTimeout = 30 * time.Minute
+)
+
+// managedClusterPollInterval is how often a managed cluster is polled while
+// waiting for it to be deleted.
+var managedClusterPollInterval = 30 * time.Second
+
+func NewManagedClusterResource() resource.Resource {
+	return &ManagedClusterResource{}
+}
+
+// ManagedClusterResource provisions a dataplane through the
+// ManagedClusterService. The service has no update, so every change to the
+// cluster replaces it.
+type ManagedClusterResource struct {
+	conn     cluster.ManagedClusterServiceClient
+	clusters cluster.ClusterServiceClient
+	org      string
+}
+
+// ManagedClusterResourceModel describes the resource data model.
+type ManagedClusterResourceModel struct {
+	Id         types.String                   `tfsdk:"id"`
+	Name       types.String                   `tfsdk:"name"`
+	Type       types.String                   `tfsdk:"type"`
+	Cloud      types.String                   `tfsdk:"cloud"`
+	Pools      types.Set                      `tfsdk:"pools"`
+	NodeGroups []ManagedClusterNodeGroupModel `tfsdk:"node_group"`
+	Timeouts   *ManagedClusterTimeoutsModel   `tfsdk:"timeouts"`
+}
+
+type ManagedClusterNodeGroupModel struct {
+	Name          types.String         `tfsdk:"name"`
+	MinNodes      types.Int64          `tfsdk:"min_nodes"`
+	MaxNodes      types.Int64          `tfsdk:"max_nodes"`
+	DiskSizeGbs   types.Int64          `tfsdk:"disk_size_gbs"`
+	InstanceTypes types.List           `tfsdk:"instance_types"`
+	SpotInstances types.Bool           `tfsdk:"spot_instances"`
+	Allocatable   types.Map            `tfsdk:"allocatable"`
+	Labels        types.Map            `tfsdk:"labels"`
+	Taints        []NodepoolTaintModel `tfsdk:"taint"`
+}
+
+type ManagedClusterTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Delete types.String `tfsdk:"delete"`
+}
+
+func (r *ManagedClusterResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_cluster"
+}
+
+func (r *ManagedClusterResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Managed cluster resource. A managed cluster is a dataplane provisioned and operated by Union.ai.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Managed cluster identifier, the same as its name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Managed cluster name. The dataplane of the cluster has the same name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString("managed_plus"),
+				MarkdownDescription: "Cluster type, one of `managed_plus` or `byok`. Defaults to `managed_plus`.",
+				Validators:          []validator.String{stringOneOf("managed_plus", "byok")},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"cloud": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Cloud the cluster runs in, one of `aws`, `gcp`, `azure`, `metal` or `oci`.",
+				Validators:          []validator.String{stringOneOf("aws", "gcp", "azure", "metal", "oci")},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"pools": schema.SetAttribute{
+				ElementType:         types.StringType,
+				Computed:            true,
+				MarkdownDescription: "Cluster pools the cluster belongs to.",
+				PlanModifiers: []planmodifier.Set{
+					setplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"node_group": schema.ListNestedBlock{
+				MarkdownDescription: "Node group of the cluster.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.RequiresReplace(),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Node group name.",
+						},
+						"min_nodes": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Minimum number of nodes.",
+						},
+						"max_nodes": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Maximum number of nodes.",
+						},
+						"disk_size_gbs": schema.Int64Attribute{
+							Optional:            true,
+							MarkdownDescription: "Disk size of a node, in GB.",
+						},
+						"instance_types": schema.ListAttribute{
+							ElementType:         types.StringType,
+							Required:            true,
+							MarkdownDescription: "Instance types of the nodes, in order of preference.",
+						},
+						"spot_instances": schema.BoolAttribute{
+							Optional:            true,
+							MarkdownDescription: "Whether the nodes are spot instances.",
+						},
+						"allocatable": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Allocatable resources of a node, keyed by `cpu`, `gpu`, `memory`, `storage` or `ephemeral_storage`.",
+							Validators:          []validator.Map{nodepoolResourceNameValidator},
+						},
+						"labels": schema.MapAttribute{
+							ElementType:         types.StringType,
+							Optional:            true,
+							MarkdownDescription: "Labels of the nodes.",
+						},
+					},
+					Blocks: map[string]schema.Block{
+						"taint": schema.ListNestedBlock{
+							MarkdownDescription: "Taint of the nodes.",
+							NestedObject: schema.NestedBlockObject{
+								Attributes: map[string]schema.Attribute{
+									"key": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Taint key.",
+									},
+									"value": schema.StringAttribute{
+										Optional:            true,
+										MarkdownDescription: "Taint value.",
+									},
+									"effect": schema.StringAttribute{
+										Required:            true,
+										MarkdownDescription: "Taint effect, one of `no_schedule`, `prefer_no_schedule` or `no_execute`.",
+										Validators:          []validator.String{nodepoolTaintEffectValidator},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "How long to wait for the cluster to be provisioned or deleted.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Timeout for create, as a Go duration (e.g. `90m`). Defaults to `60m`.",
+					},
+					"delete": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Timeout for delete, as a Go duration (e.g. `45m`). Defaults to `30m`.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ManagedClusterResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = cluster.NewManagedClusterServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cluster.ManagedClusterServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.clusters = cluster.NewClusterServiceClient(client.conn)
+	if r.clusters == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cluster.ClusterServiceClient, got: %T. Please report this issue to the provider developers.", r.clusters),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func (r *ManagedClusterResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ManagedClusterResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for i, g := range data.NodeGroups {
+		if !g.MinNodes.IsNull() && !g.MaxNodes.IsNull() && g.MinNodes.ValueInt64() > g.MaxNodes.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("node_group").AtListIndex(i).AtName("min_nodes"),
+				"Invalid Node Count",
+				"min_nodes must not be greater than max_nodes.",
+			)
+		}
+	}
+	if data.Timeouts != nil {
+		for name, value := range map[string]types.String{"create": data.Timeouts.Create, "delete": data.Timeouts.Delete} {
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			if _, err := time.ParseDuration(value.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("timeouts").AtName(name),
+					"Invalid Timeout",
+					fmt.Sprintf("Unable to parse %s timeout, got error: %s", name, err),
+				)
+			}
+		}
+	}
+}
+
+// timeout returns the configured create or delete timeout.
+func (r *ManagedClusterResource) timeout(data *ManagedClusterResourceModel, operation string) time.Duration {
+	value, fallback := types.StringNull(), defaultManagedClusterCreateTimeout
+	if operation == "delete" {
+		fallback = defaultManagedClusterDeleteTimeout
+	}
+	if data.Timeouts != nil {
+		value = data.Timeouts.Create
+		if operation == "delete" {
+			value = data.Timeouts.Delete
+		}
+	}
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return fallback
+	}
+	return timeout
+}
+
+func (r *ManagedClusterResource) managedClusterId(data *ManagedClusterResourceModel) *common.ManagedClusterIdentifier {
+	return &common.ManagedClusterIdentifier{
+		Name: data.Name.ValueString(),
+		Org:  &common.OrgIdentifier{Name: r.org},
+	}
+}
+
+// info builds the managed cluster definition from the model.
+func (r *ManagedClusterResource) info(data *ManagedClusterResourceModel) (*cluster.ManagedClusterInfo, error) {
+	clusterType, err := managedClusterType(data.Type.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	cloud, err := managedClusterCloud(data.Cloud.ValueString())
+	if err != nil {
+		return nil, err
+	}
+
+	info := &cluster.ManagedClusterInfo{
+		Id:        r.managedClusterId(data),
+		Type:      clusterType,
+		CloudType: cloud,
+	}
+	for _, g := range data.NodeGroups {
+		allocatable, err := nodepoolResourceEntries(g.Allocatable)
+		if err != nil {
+			return nil, err
+		}
+		taints, err := nodepoolTaints(g.Taints)
+		if err != nil {
+			return nil, err
+		}
+		info.NodeGroups = append(info.NodeGroups, &cluster.NodeGroup{
+			Name:                 g.Name.ValueString(),
+			MinNodes:             uint32(g.MinNodes.ValueInt64()),
+			MaxNodes:             uint32(g.MaxNodes.ValueInt64()),
+			NodeDiskSizeGbs:      uint32(g.DiskSizeGbs.ValueInt64()),
+			NodeInstanceTypes:    convertListToStrings(g.InstanceTypes),
+			SpotInstances:        g.SpotInstances.ValueBool(),
+			AllocatableResources: allocatable,
+			Labels:               convertMapToStrings(g.Labels),
+			Taints:               taints,
+		})
+	}
+	return info, nil
+}
+
+// refresh copies the remote managed cluster into the model.
+func (r *ManagedClusterResource) refresh(data *ManagedClusterResourceModel, mc *cluster.ManagedCluster) {
+	info := mc.GetInfo()
+	data.Id = types.StringValue(info.GetId().GetName())
+	data.Name = types.StringValue(info.GetId().GetName())
+	data.Type = types.StringValue(strings.TrimPrefix(strings.ToLower(info.GetType().String()), "cluster_type_"))
+	data.Cloud = types.StringValue(strings.TrimPrefix(strings.ToLower(info.GetCloudType().String()), "cloud_"))
+	data.Pools = convertArrayToSetGetter(mc.GetPools(), func(pool *common.ClusterPoolIdentifier) string {
+		return pool.GetName()
+	})
+
+	prior := data.NodeGroups
+	data.NodeGroups = nil
+	for i, g := range info.GetNodeGroups() {
+		var p ManagedClusterNodeGroupModel
+		if i < len(prior) {
+			p = prior[i]
+		}
+		group := ManagedClusterNodeGroupModel{
+			Name:          types.StringValue(g.GetName()),
+			MinNodes:      optionalInt64(p.MinNodes, int64(g.GetMinNodes())),
+			MaxNodes:      optionalInt64(p.MaxNodes, int64(g.GetMaxNodes())),
+			DiskSizeGbs:   optionalInt64(p.DiskSizeGbs, int64(g.GetNodeDiskSizeGbs())),
+			InstanceTypes: convertStringsToList(g.GetNodeInstanceTypes()),
+			SpotInstances: optionalBool(p.SpotInstances, g.GetSpotInstances()),
+			Allocatable:   nodepoolResourceMap(g.GetAllocatableResources()),
+			Labels:        convertStringsToMap(g.GetLabels()),
+			Taints:        nodepoolTaintModels(g.GetTaints()),
+		}
+		if i < len(prior) {
+			group = managedClusterNodeGroupKeepPrior(p, group)
+		}
+		data.NodeGroups = append(data.NodeGroups, group)
+	}
+}
+
+// managedClusterNodeGroupKeepPrior keeps the prior value of node group
+// attributes that would otherwise show a diff, since any change to a node
+// group replaces the cluster: attributes left unset are filled in by the
+// server, and empty maps and taint lists are read back as unset.
+func managedClusterNodeGroupKeepPrior(prior, group ManagedClusterNodeGroupModel) ManagedClusterNodeGroupModel {
+	if prior.MinNodes.IsNull() {
+		group.MinNodes = prior.MinNodes
+	}
+	if prior.MaxNodes.IsNull() {
+		group.MaxNodes = prior.MaxNodes
+	}
+	if prior.DiskSizeGbs.IsNull() {
+		group.DiskSizeGbs = prior.DiskSizeGbs
+	}
+	if prior.SpotInstances.IsNull() {
+		group.SpotInstances = prior.SpotInstances
+	}
+	if prior.Allocatable.IsNull() || maps.Equal(convertMapToStrings(prior.Allocatable), convertMapToStrings(group.Allocatable)) {
+		group.Allocatable = prior.Allocatable
+	}
+	if prior.Labels.IsNull() || maps.Equal(convertMapToStrings(prior.Labels), convertMapToStrings(group.Labels)) {
+		group.Labels = prior.Labels
+	}
+	if slices.Equal(prior.Taints, group.Taints) {
+		group.Taints = prior.Taints
+	}
+	return group
+}
+
+// managedClusterTypes maps the configured cluster types to their API values.
+// They are the enum names without prefix, lower-cased as read back by refresh.
+var managedClusterTypes = map[string]cluster.ClusterType{
+	"managed_plus": cluster.ClusterType_CLUSTER_TYPE_MANAGED_PLUS,
+	"byok":         cluster.ClusterType_CLUSTER_TYPE_BYOK,
+}
+
+// managedClusterClouds maps the configured clouds to their API values.
+var managedClusterClouds = map[string]cluster.Cloud{
+	"aws":   cluster.Cloud_CLOUD_AWS,
+	"gcp":   cluster.Cloud_CLOUD_GCP,
+	"azure": cluster.Cloud_CLOUD_AZURE,
+	"metal": cluster.Cloud_CLOUD_METAL,
+	"oci":   cluster.Cloud_CLOUD_OCI,
+}
+
+func managedClusterType(value string) (cluster.ClusterType, error) {
+	t, ok := managedClusterTypes[value]
+	if !ok {
+		return 0, fmt.Errorf("invalid cluster type %q, must be one of managed_plus or byok", value)
+	}
+	return t, nil
+}
+
+func managedClusterCloud(value string) (cluster.Cloud, error) {
+	c, ok := managedClusterClouds[value]
+	if !ok {
+		return 0, fmt.Errorf("invalid cloud %q, must be one of aws, gcp, azure, metal or oci", value)
+	}
+	return c, nil
+}
+
+// waitForDeleted polls the managed cluster until it is gone or the timeout
+// expires.
+func (r *ManagedClusterResource) waitForDeleted(ctx context.Context, id *common.ManagedClusterIdentifier, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		_, err := r.conn.Get(ctx, &cluster.ManagedClusterServiceGetRequest{Id: id})
+		switch {
+		case status.Code(err) == codes.NotFound:
+			return nil
+		case err != nil && ctx.Err() == nil:
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("managed cluster %s was not deleted within %s", id.GetName(), timeout)
+		case <-time.After(managedClusterPollInterval):
+		}
+	}
+}
+
+func (r *ManagedClusterResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ManagedClusterResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	info, err := r.info(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", fmt.Sprintf("Unable to build managed cluster %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	if _, err := r.conn.Create(ctx, &cluster.ManagedClusterServiceCreateRequest{Info: info}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create managed cluster %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	got, err := r.conn.Get(ctx, &cluster.ManagedClusterServiceGetRequest{Id: info.GetId()})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed cluster %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refresh(&data, got.GetCluster())
+
+	// Save data into Terraform state before waiting, so a failed wait leaves
+	// the cluster tracked
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The dataplane only registers once provisioning has progressed far
+	// enough, until then it is not found
+	if _, err := waitForHealthyDataplane(ctx, r.clusters, r.org, data.Name.ValueString(), r.timeout(&data, "create")); err != nil {
+		resp.Diagnostics.AddError("Managed Cluster Not Ready", fmt.Sprintf("Managed cluster %s was created but is not ready, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+func (r *ManagedClusterResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ManagedClusterResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.Get(ctx, &cluster.ManagedClusterServiceGetRequest{Id: r.managedClusterId(&data)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read managed cluster %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	r.refresh(&data, got.GetCluster())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Update only persists changes to the timeouts, every other attribute
+// requires replacement.
+func (r *ManagedClusterResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ManagedClusterResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ManagedClusterResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ManagedClusterResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := r.managedClusterId(&data)
+	_, err := r.conn.Delete(ctx, &cluster.ManagedClusterServiceDeleteRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete managed cluster %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	if err := r.waitForDeleted(ctx, id, r.timeout(&data, "delete")); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete managed cluster %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+func (r *ManagedClusterResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), req.ID)...)
+}
@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockManagedClusterClient implements the subset of
+// cluster.ManagedClusterServiceClient used by the managed cluster resource.
+// Get reports the cluster until it has been polled deleteAfter times after a
+// delete.
+type mockManagedClusterClient struct {
+	cluster.ManagedClusterServiceClient
+	cluster     *cluster.ManagedCluster
+	deleted     bool
+	deleteAfter int
+}
+
+func (m *mockManagedClusterClient) Get(ctx context.Context, in *cluster.ManagedClusterServiceGetRequest, opts ...grpc.CallOption) (*cluster.ManagedClusterServiceGetResponse, error) {
+	if m.deleted {
+		if m.deleteAfter == 0 {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+		m.deleteAfter--
+	}
+	return &cluster.ManagedClusterServiceGetResponse{Cluster: m.cluster}, nil
+}
+
+func (m *mockManagedClusterClient) Delete(ctx context.Context, in *cluster.ManagedClusterServiceDeleteRequest, opts ...grpc.CallOption) (*cluster.ManagedClusterServiceDeleteResponse, error) {
+	m.deleted = true
+	return &cluster.ManagedClusterServiceDeleteResponse{}, nil
+}
+
+// managedClusterNodeGroupsEqual reports whether two node group lists would
+// plan without a diff.
+func managedClusterNodeGroupsEqual(a, b []ManagedClusterNodeGroupModel) bool {
+	return slices.EqualFunc(a, b, func(x, y ManagedClusterNodeGroupModel) bool {
+		return x.Name.Equal(y.Name) &&
+			x.MinNodes.Equal(y.MinNodes) &&
+			x.MaxNodes.Equal(y.MaxNodes) &&
+			x.DiskSizeGbs.Equal(y.DiskSizeGbs) &&
+			x.InstanceTypes.Equal(y.InstanceTypes) &&
+			x.SpotInstances.Equal(y.SpotInstances) &&
+			x.Allocatable.Equal(y.Allocatable) &&
+			x.Labels.Equal(y.Labels) &&
+			slices.Equal(x.Taints, y.Taints) && (x.Taints == nil) == (y.Taints == nil)
+	})
+}
+
+func TestManagedClusterResource_RoundTrip(t *testing.T) {
+	r := &ManagedClusterResource{org: "test-org"}
+	data := &ManagedClusterResourceModel{
+		Name:  types.StringValue("prod"),
+		Type:  types.StringValue("managed_plus"),
+		Cloud: types.StringValue("aws"),
+		NodeGroups: []ManagedClusterNodeGroupModel{{
+			Name:          types.StringValue("gpu"),
+			MinNodes:      types.Int64Null(),
+			MaxNodes:      types.Int64Value(8),
+			DiskSizeGbs:   types.Int64Value(500),
+			InstanceTypes: types.ListValueMust(types.StringType, []attr.Value{types.StringValue("p4d.24xlarge")}),
+			SpotInstances: types.BoolNull(),
+			Allocatable:   types.MapValueMust(types.StringType, map[string]attr.Value{"gpu": types.StringValue("8")}),
+			Labels:        types.MapNull(types.StringType),
+			Taints: []NodepoolTaintModel{{
+				Key:    types.StringValue("nvidia.com/gpu"),
+				Value:  types.StringNull(),
+				Effect: types.StringValue("no_schedule"),
+			}},
+		}},
+	}
+
+	info, err := r.info(data)
+	if err != nil {
+		t.Fatalf("info() returned error: %s", err)
+	}
+	if info.GetType() != cluster.ClusterType_CLUSTER_TYPE_MANAGED_PLUS || info.GetCloudType() != cluster.Cloud_CLOUD_AWS {
+		t.Errorf("Unexpected cluster type or cloud: %v", info)
+	}
+	group := info.GetNodeGroups()[0]
+	if group.GetMaxNodes() != 8 || group.GetNodeDiskSizeGbs() != 500 || group.GetNodeInstanceTypes()[0] != "p4d.24xlarge" || len(group.GetTaints()) != 1 {
+		t.Errorf("Unexpected node group: %v", group)
+	}
+
+	// The server fills in defaults for unset attributes
+	info.GetNodeGroups()[0].MinNodes = 1
+	info.GetNodeGroups()[0].SpotInstances = true
+	info.GetNodeGroups()[0].Labels = map[string]string{"union.ai/pool": "gpu"}
+
+	refreshed := &ManagedClusterResourceModel{NodeGroups: slices.Clone(data.NodeGroups)}
+	r.refresh(refreshed, &cluster.ManagedCluster{
+		Info:  info,
+		Pools: []*common.ClusterPoolIdentifier{{Organization: "test-org", Name: "gpu-pool"}},
+	})
+	if refreshed.Id.ValueString() != "prod" || refreshed.Type.ValueString() != "managed_plus" || refreshed.Cloud.ValueString() != "aws" {
+		t.Errorf("Unexpected refreshed cluster: %+v", refreshed)
+	}
+	if len(refreshed.Pools.Elements()) != 1 {
+		t.Errorf("Expected one pool, got %v", refreshed.Pools)
+	}
+	if !managedClusterNodeGroupsEqual(refreshed.NodeGroups, data.NodeGroups) {
+		t.Errorf("Node group drifted after refresh: %+v", refreshed.NodeGroups[0])
+	}
+
+	// Empty maps and taint lists are read back as configured
+	data.NodeGroups[0].Labels = types.MapValueMust(types.StringType, map[string]attr.Value{})
+	data.NodeGroups[0].Taints = []NodepoolTaintModel{}
+	info.GetNodeGroups()[0].Labels = nil
+	info.GetNodeGroups()[0].Taints = nil
+	refreshed.NodeGroups = slices.Clone(data.NodeGroups)
+	r.refresh(refreshed, &cluster.ManagedCluster{Info: info})
+	if !managedClusterNodeGroupsEqual(refreshed.NodeGroups, data.NodeGroups) {
+		t.Errorf("Empty node group values drifted after refresh: %+v", refreshed.NodeGroups[0])
+	}
+
+	// Imported node groups are read back as they are stored
+	refreshed.NodeGroups = nil
+	r.refresh(refreshed, &cluster.ManagedCluster{Info: info})
+	if got := refreshed.NodeGroups[0]; got.MinNodes.ValueInt64() != 1 || !got.SpotInstances.ValueBool() {
+		t.Errorf("Expected the imported node group to hold the server values, got %+v", got)
+	}
+
+	for _, cloud := range []string{"ibm", "AWS"} {
+		data.Cloud = types.StringValue(cloud)
+		if _, err := r.info(data); err == nil {
+			t.Errorf("Expected cloud %q to be rejected", cloud)
+		}
+	}
+
+	// Every accepted value must be the one read back
+	for name, value := range managedClusterClouds {
+		r.refresh(refreshed, &cluster.ManagedCluster{Info: &cluster.ManagedClusterInfo{CloudType: value}})
+		if refreshed.Cloud.ValueString() != name {
+			t.Errorf("Cloud %q is read back as %q", name, refreshed.Cloud.ValueString())
+		}
+	}
+	for name, value := range managedClusterTypes {
+		r.refresh(refreshed, &cluster.ManagedCluster{Info: &cluster.ManagedClusterInfo{Type: value}})
+		if refreshed.Type.ValueString() != name {
+			t.Errorf("Cluster type %q is read back as %q", name, refreshed.Type.ValueString())
+		}
+	}
+}
+
+func TestManagedClusterResource_WaitForDeleted(t *testing.T) {
+	defer func(interval time.Duration) { managedClusterPollInterval = interval }(managedClusterPollInterval)
+	managedClusterPollInterval = time.Millisecond
+
+	conn := &mockManagedClusterClient{deleted: true, deleteAfter: 2}
+	r := &ManagedClusterResource{org: "test-org", conn: conn}
+	id := &common.ManagedClusterIdentifier{Name: "prod", Org: &common.OrgIdentifier{Name: "test-org"}}
+
+	if err := r.waitForDeleted(context.Background(), id, time.Second); err != nil {
+		t.Fatalf("waitForDeleted() returned error: %s", err)
+	}
+
+	conn.deleteAfter = 1 << 30
+	if err := r.waitForDeleted(context.Background(), id, 20*time.Millisecond); err == nil {
+		t.Error("Expected a timeout while the cluster is still being deleted")
+	}
+}
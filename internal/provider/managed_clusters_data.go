@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &ManagedClustersDataSource{}
+
+func NewManagedClustersDataSource() datasource.DataSource {
+	return &ManagedClustersDataSource{}
+}
+
+// ManagedClustersDataSource defines the data source implementation.
+type ManagedClustersDataSource struct {
+	conn cluster.ManagedClusterServiceClient
+	org  string
+}
+
+// ManagedClustersDataSourceModel describes the data source data model.
+type ManagedClustersDataSourceModel struct {
+	Clusters []ManagedClusterDataSourceModel `tfsdk:"clusters"`
+}
+
+type ManagedClusterDataSourceModel struct {
+	Name       types.String                             `tfsdk:"name"`
+	Type       types.String                             `tfsdk:"type"`
+	Cloud      types.String                             `tfsdk:"cloud"`
+	Pools      types.Set                                `tfsdk:"pools"`
+	NodeGroups []ManagedClusterNodeGroupDataSourceModel `tfsdk:"node_groups"`
+}
+
+type ManagedClusterNodeGroupDataSourceModel struct {
+	Name             types.String                     `tfsdk:"name"`
+	MinNodes         types.Int64                      `tfsdk:"min_nodes"`
+	MaxNodes         types.Int64                      `tfsdk:"max_nodes"`
+	DiskSizeGbs      types.Int64                      `tfsdk:"disk_size_gbs"`
+	InstanceTypes    types.List                       `tfsdk:"instance_types"`
+	SpotInstances    types.Bool                       `tfsdk:"spot_instances"`
+	Labels           types.Map                        `tfsdk:"labels"`
+	DisplayResources []DisplayResourceDataSourceModel `tfsdk:"display_resources"`
+}
+
+type DisplayResourceDataSourceModel struct {
+	Name  types.String  `tfsdk:"name"`
+	Value types.Float64 `tfsdk:"value"`
+	Unit  types.String  `tfsdk:"unit"`
+}
+
+func (d *ManagedClustersDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_managed_clusters"
+}
+
+func (d *ManagedClustersDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Managed clusters data source",
+
+		Attributes: map[string]schema.Attribute{
+			"clusters": schema.ListNestedAttribute{
+				MarkdownDescription: "Managed clusters",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Managed cluster name",
+							Computed:            true,
+						},
+						"type": schema.StringAttribute{
+							MarkdownDescription: "Cluster type",
+							Computed:            true,
+						},
+						"cloud": schema.StringAttribute{
+							MarkdownDescription: "Cloud the cluster runs in",
+							Computed:            true,
+						},
+						"pools": schema.SetAttribute{
+							MarkdownDescription: "Cluster pools the cluster belongs to",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"node_groups": schema.ListNestedAttribute{
+							MarkdownDescription: "Node groups of the cluster",
+							Computed:            true,
+							NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"name": schema.StringAttribute{
+										MarkdownDescription: "Node group name",
+										Computed:            true,
+									},
+									"min_nodes": schema.Int64Attribute{
+										MarkdownDescription: "Minimum number of nodes",
+										Computed:            true,
+									},
+									"max_nodes": schema.Int64Attribute{
+										MarkdownDescription: "Maximum number of nodes",
+										Computed:            true,
+									},
+									"disk_size_gbs": schema.Int64Attribute{
+										MarkdownDescription: "Disk size of a node, in GB",
+										Computed:            true,
+									},
+									"instance_types": schema.ListAttribute{
+										MarkdownDescription: "Instance types of the nodes",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+									"spot_instances": schema.BoolAttribute{
+										MarkdownDescription: "Whether the nodes are spot instances",
+										Computed:            true,
+									},
+									"labels": schema.MapAttribute{
+										MarkdownDescription: "Labels of the nodes",
+										Computed:            true,
+										ElementType:         types.StringType,
+									},
+									"display_resources": schema.ListNestedAttribute{
+										MarkdownDescription: "Resources of a node, as displayed to users",
+										Computed:            true,
+										NestedObject: schema.NestedAttributeObject{
+											Attributes: map[string]schema.Attribute{
+												"name": schema.StringAttribute{
+													MarkdownDescription: "Resource name",
+													Computed:            true,
+												},
+												"value": schema.Float64Attribute{
+													MarkdownDescription: "Resource quantity",
+													Computed:            true,
+												},
+												"unit": schema.StringAttribute{
+													MarkdownDescription: "Unit of the quantity",
+													Computed:            true,
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *ManagedClustersDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.conn = cluster.NewManagedClusterServiceClient(client.conn)
+	if d.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cluster.ManagedClusterServiceClient, got: %T. Please report this issue to the provider developers.", d.conn),
+		)
+		return
+	}
+	d.org = client.org
+}
+
+// listDisplayInfo pages through all managed clusters, including the display
+// information of their node groups.
+func (d *ManagedClustersDataSource) listDisplayInfo(ctx context.Context) ([]*cluster.ManagedCluster, error) {
+	var clusters []*cluster.ManagedCluster
+	token := ""
+	for {
+		resp, err := d.conn.ListDisplayInfo(ctx, &cluster.ManagedClusterServiceListDisplayInfoRequest{
+			Request: &common.ListRequest{
+				Limit: 100,
+				Token: token,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		clusters = append(clusters, resp.GetClusters()...)
+		if resp.GetToken() == "" || len(resp.GetClusters()) == 0 {
+			return clusters, nil
+		}
+		token = resp.GetToken()
+	}
+}
+
+func (d *ManagedClustersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data ManagedClustersDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	clusters, err := d.listDisplayInfo(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch managed clusters", err.Error())
+		return
+	}
+
+	data.Clusters = []ManagedClusterDataSourceModel{}
+	for _, mc := range clusters {
+		info := mc.GetInfo()
+		model := ManagedClusterDataSourceModel{
+			Name:  types.StringValue(info.GetId().GetName()),
+			Type:  types.StringValue(strings.TrimPrefix(strings.ToLower(info.GetType().String()), "cluster_type_")),
+			Cloud: types.StringValue(strings.TrimPrefix(strings.ToLower(info.GetCloudType().String()), "cloud_")),
+			Pools: convertArrayToSetGetter(mc.GetPools(), func(pool *common.ClusterPoolIdentifier) string {
+				return pool.GetName()
+			}),
+			NodeGroups: []ManagedClusterNodeGroupDataSourceModel{},
+		}
+		for _, g := range info.GetNodeGroups() {
+			group := ManagedClusterNodeGroupDataSourceModel{
+				Name:             types.StringValue(g.GetName()),
+				MinNodes:         types.Int64Value(int64(g.GetMinNodes())),
+				MaxNodes:         types.Int64Value(int64(g.GetMaxNodes())),
+				DiskSizeGbs:      types.Int64Value(int64(g.GetNodeDiskSizeGbs())),
+				InstanceTypes:    convertStringsToList(g.GetNodeInstanceTypes()),
+				SpotInstances:    types.BoolValue(g.GetSpotInstances()),
+				Labels:           convertStringsToMap(g.GetLabels()),
+				DisplayResources: []DisplayResourceDataSourceModel{},
+			}
+			for _, r := range g.GetDisplayInfo().GetDisplayableResources() {
+				group.DisplayResources = append(group.DisplayResources, DisplayResourceDataSourceModel{
+					Name:  types.StringValue(strings.ToLower(r.GetName().String())),
+					Value: types.Float64Value(r.GetQuantity().GetValue()),
+					Unit:  types.StringValue(r.GetQuantity().GetUnit()),
+				})
+			}
+			model.NodeGroups = append(model.NodeGroups, group)
+		}
+		data.Clusters = append(data.Clusters, model)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
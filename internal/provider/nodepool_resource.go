@@ -0,0 +1,591 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &NodepoolResource{}
+var _ resource.ResourceWithImportState = &NodepoolResource{}
+var _ resource.ResourceWithValidateConfig = &NodepoolResource{}
+
+func NewNodepoolResource() resource.Resource {
+	return &NodepoolResource{}
+}
+
+// NodepoolResource manages a nodepool of a dataplane through the
+// ClusterNodepoolService. Create and update both upsert the nodepool in place.
+type NodepoolResource struct {
+	conn cluster.ClusterNodepoolServiceClient
+	org  string
+}
+
+// NodepoolResourceModel describes the resource data model.
+type NodepoolResourceModel struct {
+	Id        types.String            `tfsdk:"id"`
+	Dataplane types.String            `tfsdk:"dataplane"`
+	Name      types.String            `tfsdk:"name"`
+	Direct    *NodepoolDirectModel    `tfsdk:"direct"`
+	Reference *NodepoolReferenceModel `tfsdk:"reference"`
+	Status    types.Object            `tfsdk:"status"`
+}
+
+type NodepoolDirectModel struct {
+	Labels      types.Map            `tfsdk:"labels"`
+	MinNodes    types.Int64          `tfsdk:"min_nodes"`
+	MaxNodes    types.Int64          `tfsdk:"max_nodes"`
+	Allocatable types.Map            `tfsdk:"allocatable"`
+	Taints      []NodepoolTaintModel `tfsdk:"taint"`
+}
+
+type NodepoolReferenceModel struct {
+	Provider types.String `tfsdk:"provider"`
+	Id       types.String `tfsdk:"id"`
+}
+
+type NodepoolTaintModel struct {
+	Key    types.String `tfsdk:"key"`
+	Value  types.String `tfsdk:"value"`
+	Effect types.String `tfsdk:"effect"`
+}
+
+type NodepoolStatusModel struct {
+	Labels    types.Map            `tfsdk:"labels"`
+	Taints    []NodepoolTaintModel `tfsdk:"taints"`
+	Projected types.Map            `tfsdk:"projected"`
+	Observed  types.Map            `tfsdk:"observed"`
+}
+
+var nodepoolTaintAttrTypes = map[string]attr.Type{
+	"key":    types.StringType,
+	"value":  types.StringType,
+	"effect": types.StringType,
+}
+
+var nodepoolStatusAttrTypes = map[string]attr.Type{
+	"labels":    types.MapType{ElemType: types.StringType},
+	"taints":    types.ListType{ElemType: types.ObjectType{AttrTypes: nodepoolTaintAttrTypes}},
+	"projected": types.MapType{ElemType: types.StringType},
+	"observed":  types.MapType{ElemType: types.StringType},
+}
+
+func (r *NodepoolResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nodepool"
+}
+
+func (r *NodepoolResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	taintAttributes := map[string]schema.Attribute{
+		"key": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Taint key.",
+		},
+		"value": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Taint value.",
+		},
+		"effect": schema.StringAttribute{
+			Computed:            true,
+			MarkdownDescription: "Taint effect.",
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nodepool resource. A nodepool is a group of nodes of a dataplane that workloads are scheduled on.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Nodepool identifier, in the form `{dataplane}/{name}`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"dataplane": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Dataplane the nodepool belongs to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Nodepool name, unique per dataplane.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"status": schema.SingleNestedAttribute{
+				Computed:            true,
+				MarkdownDescription: "Nodepool status as observed on the dataplane.",
+				Attributes: map[string]schema.Attribute{
+					"labels": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Computed:            true,
+						MarkdownDescription: "Labels of the nodes.",
+					},
+					"taints": schema.ListNestedAttribute{
+						Computed:            true,
+						MarkdownDescription: "Taints of the nodes.",
+						NestedObject: schema.NestedAttributeObject{
+							Attributes: taintAttributes,
+						},
+					},
+					"projected": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Computed:            true,
+						MarkdownDescription: "Projected allocatable resources of a node, keyed by resource name.",
+					},
+					"observed": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Computed:            true,
+						MarkdownDescription: "Observed allocatable resources of a node, keyed by resource name.",
+					},
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"direct": schema.SingleNestedBlock{
+				MarkdownDescription: "Nodepool defined directly by its node properties. Conflicts with `reference`.",
+				Attributes: map[string]schema.Attribute{
+					"labels": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Labels of the nodes.",
+					},
+					"min_nodes": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Minimum number of nodes.",
+					},
+					"max_nodes": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Maximum number of nodes.",
+					},
+					"allocatable": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Allocatable resources of a node, keyed by `cpu`, `gpu`, `memory`, `storage` or `ephemeral_storage`.",
+						Validators:          []validator.Map{nodepoolResourceNameValidator},
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"taint": schema.ListNestedBlock{
+						MarkdownDescription: "Taint of the nodes.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"key": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Taint key.",
+								},
+								"value": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "Taint value.",
+								},
+								"effect": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Taint effect, one of `no_schedule`, `prefer_no_schedule` or `no_execute`.",
+									Validators:          []validator.String{nodepoolTaintEffectValidator},
+								},
+							},
+						},
+					},
+				},
+			},
+			"reference": schema.SingleNestedBlock{
+				MarkdownDescription: "Nodepool backed by a node group of the cloud provider. Conflicts with `direct`.",
+				Attributes: map[string]schema.Attribute{
+					"provider": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Provider of the node group (e.g. `aws`).",
+					},
+					"id": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Identifier of the node group at the provider.",
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *NodepoolResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = cluster.NewClusterNodepoolServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *cluster.ClusterNodepoolServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func (r *NodepoolResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data NodepoolResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (data.Direct == nil) == (data.Reference == nil) {
+		resp.Diagnostics.AddError(
+			"Invalid Nodepool Spec",
+			"Exactly one of the direct or reference blocks must be set.",
+		)
+	}
+	if data.Reference != nil && (data.Reference.Provider.IsNull() || data.Reference.Id.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("reference"),
+			"Incomplete Nodepool Reference",
+			"The reference block requires both provider and id.",
+		)
+	}
+	if d := data.Direct; d != nil && !d.MinNodes.IsNull() && !d.MaxNodes.IsNull() && d.MinNodes.ValueInt64() > d.MaxNodes.ValueInt64() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("direct").AtName("min_nodes"),
+			"Invalid Node Count",
+			"min_nodes must not be greater than max_nodes.",
+		)
+	}
+}
+
+func (r *NodepoolResource) nodepoolId(data *NodepoolResourceModel) *common.ClusterNodepoolIdentifier {
+	return &common.ClusterNodepoolIdentifier{
+		Organization: r.org,
+		ClusterName:  data.Dataplane.ValueString(),
+		Name:         data.Name.ValueString(),
+	}
+}
+
+// nodepool builds the nodepool from the model.
+func (r *NodepoolResource) nodepool(data *NodepoolResourceModel) (*cluster.Nodepool, error) {
+	nodepool := &cluster.Nodepool{Id: r.nodepoolId(data)}
+
+	switch {
+	case data.Direct != nil:
+		allocatable, err := nodepoolResourceEntries(data.Direct.Allocatable)
+		if err != nil {
+			return nil, err
+		}
+		taints, err := nodepoolTaints(data.Direct.Taints)
+		if err != nil {
+			return nil, err
+		}
+		nodepool.Spec = &cluster.Nodepool_Direct{
+			Direct: &cluster.NodepoolDirectSpec{
+				Labels:      convertMapToStrings(data.Direct.Labels),
+				Taints:      taints,
+				MinNodes:    uint32(data.Direct.MinNodes.ValueInt64()),
+				MaxNodes:    uint32(data.Direct.MaxNodes.ValueInt64()),
+				Allocatable: allocatable,
+			},
+		}
+	case data.Reference != nil:
+		nodepool.Spec = &cluster.Nodepool_Reference{
+			Reference: &cluster.NodepoolReferenceSpec{
+				Provider: data.Reference.Provider.ValueString(),
+				Id:       data.Reference.Id.ValueString(),
+			},
+		}
+	}
+	return nodepool, nil
+}
+
+// refresh copies the remote nodepool into the model.
+func (r *NodepoolResource) refresh(ctx context.Context, data *NodepoolResourceModel, nodepool *cluster.Nodepool) diag.Diagnostics {
+	id := nodepool.GetId()
+	data.Id = types.StringValue(nodepoolId(id))
+	data.Dataplane = types.StringValue(id.GetClusterName())
+	data.Name = types.StringValue(id.GetName())
+
+	var prior NodepoolDirectModel
+	if data.Direct != nil {
+		prior = *data.Direct
+	}
+	data.Direct = nil
+	data.Reference = nil
+	switch {
+	case nodepool.GetDirect() != nil:
+		direct := nodepool.GetDirect()
+		data.Direct = &NodepoolDirectModel{
+			Labels:      convertStringsToMap(direct.GetLabels()),
+			MinNodes:    optionalInt64(prior.MinNodes, int64(direct.GetMinNodes())),
+			MaxNodes:    optionalInt64(prior.MaxNodes, int64(direct.GetMaxNodes())),
+			Allocatable: nodepoolResourceMap(direct.GetAllocatable()),
+			Taints:      nodepoolTaintModels(direct.GetTaints()),
+		}
+	case nodepool.GetReference() != nil:
+		data.Reference = &NodepoolReferenceModel{
+			Provider: types.StringValue(nodepool.GetReference().GetProvider()),
+			Id:       types.StringValue(nodepool.GetReference().GetId()),
+		}
+	}
+
+	var diags diag.Diagnostics
+	data.Status, diags = nodepoolStatus(ctx, nodepool.GetStatus())
+	return diags
+}
+
+func nodepoolStatus(ctx context.Context, s *cluster.NodepoolStatus) (types.Object, diag.Diagnostics) {
+	if s == nil {
+		return types.ObjectNull(nodepoolStatusAttrTypes), nil
+	}
+	taints := nodepoolTaintModels(s.GetTaints())
+	if taints == nil {
+		taints = []NodepoolTaintModel{}
+	}
+	return types.ObjectValueFrom(ctx, nodepoolStatusAttrTypes, NodepoolStatusModel{
+		Labels:    convertStringsToMap(s.GetLabels()),
+		Taints:    taints,
+		Projected: nodepoolResourceMap(s.GetAllocatable().GetProjected()),
+		Observed:  nodepoolResourceMap(s.GetAllocatable().GetObserved()),
+	})
+}
+
+// nodepoolTaintEffects maps the configured taint effects to their API values.
+// They are the lower-cased enum names, as read back by nodepoolTaintModels.
+var nodepoolTaintEffects = map[string]cluster.TaintEffect{
+	"no_schedule":        cluster.TaintEffect_NO_SCHEDULE,
+	"prefer_no_schedule": cluster.TaintEffect_PREFER_NO_SCHEDULE,
+	"no_execute":         cluster.TaintEffect_NO_EXECUTE,
+}
+
+var nodepoolTaintEffectValidator = stringOneOf("no_schedule", "prefer_no_schedule", "no_execute")
+
+// nodepoolResourceNames maps the configured resource names to their API
+// values. They are the lower-cased enum names, as read back by
+// nodepoolResourceMap.
+var nodepoolResourceNames = map[string]core.Resources_ResourceName{
+	"cpu":               core.Resources_CPU,
+	"gpu":               core.Resources_GPU,
+	"memory":            core.Resources_MEMORY,
+	"storage":           core.Resources_STORAGE,
+	"ephemeral_storage": core.Resources_EPHEMERAL_STORAGE,
+}
+
+var nodepoolResourceNameValidator = mapKeysOneOf("cpu", "gpu", "memory", "storage", "ephemeral_storage")
+
+func nodepoolTaints(models []NodepoolTaintModel) ([]*cluster.Taint, error) {
+	taints := make([]*cluster.Taint, 0, len(models))
+	for _, m := range models {
+		effect, ok := nodepoolTaintEffects[m.Effect.ValueString()]
+		if !ok {
+			return nil, fmt.Errorf("invalid taint effect %q, must be one of no_schedule, prefer_no_schedule or no_execute", m.Effect.ValueString())
+		}
+		taints = append(taints, &cluster.Taint{
+			Key:    m.Key.ValueString(),
+			Value:  m.Value.ValueString(),
+			Effect: effect,
+		})
+	}
+	return taints, nil
+}
+
+func nodepoolTaintModels(taints []*cluster.Taint) []NodepoolTaintModel {
+	var models []NodepoolTaintModel
+	for _, t := range taints {
+		models = append(models, NodepoolTaintModel{
+			Key:    types.StringValue(t.GetKey()),
+			Value:  optionalString(t.GetValue()),
+			Effect: types.StringValue(strings.ToLower(t.GetEffect().String())),
+		})
+	}
+	return models
+}
+
+func nodepoolResourceEntries(input types.Map) ([]*core.Resources_ResourceEntry, error) {
+	values := convertMapToStrings(input)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*core.Resources_ResourceEntry, 0, len(keys))
+	for _, key := range keys {
+		name, ok := nodepoolResourceNames[key]
+		if !ok {
+			return nil, fmt.Errorf("invalid resource %q, must be one of cpu, gpu, memory, storage or ephemeral_storage", key)
+		}
+		entries = append(entries, &core.Resources_ResourceEntry{
+			Name:  name,
+			Value: values[key],
+		})
+	}
+	return entries, nil
+}
+
+func nodepoolResourceMap(entries []*core.Resources_ResourceEntry) types.Map {
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		values[strings.ToLower(entry.GetName().String())] = entry.GetValue()
+	}
+	return convertStringsToMap(values)
+}
+
+// upsert writes the nodepool and reads it back, as the upsert response does
+// not carry the nodepool.
+func (r *NodepoolResource) upsert(ctx context.Context, data *NodepoolResourceModel) (*cluster.Nodepool, error) {
+	nodepool, err := r.nodepool(data)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := r.conn.UpsertNodepool(ctx, &cluster.UpsertNodepoolRequest{Nodepool: nodepool}); err != nil {
+		return nil, err
+	}
+	got, err := r.conn.GetNodepool(ctx, &cluster.GetNodepoolRequest{Id: nodepool.GetId()})
+	if err != nil {
+		return nil, err
+	}
+	return got.GetNodepool(), nil
+}
+
+func (r *NodepoolResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data NodepoolResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodepool, err := r.upsert(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create nodepool %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	data.Id = types.StringValue(nodepoolId(nodepool.GetId()))
+	var diags diag.Diagnostics
+	data.Status, diags = nodepoolStatus(ctx, nodepool.GetStatus())
+	resp.Diagnostics.Append(diags...)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NodepoolResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data NodepoolResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.GetNodepool(ctx, &cluster.GetNodepoolRequest{Id: r.nodepoolId(&data)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read nodepool %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	resp.Diagnostics.Append(r.refresh(ctx, &data, got.GetNodepool())...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NodepoolResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data NodepoolResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodepool, err := r.upsert(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update nodepool %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	data.Id = types.StringValue(nodepoolId(nodepool.GetId()))
+	var diags diag.Diagnostics
+	data.Status, diags = nodepoolStatus(ctx, nodepool.GetStatus())
+	resp.Diagnostics.Append(diags...)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *NodepoolResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data NodepoolResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.conn.DeleteNodepool(ctx, &cluster.DeleteNodepoolRequest{Id: r.nodepoolId(&data)})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete nodepool %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+// ImportState accepts an identifier in the form "{dataplane}/{name}".
+func (r *NodepoolResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier in the form \"dataplane/name\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("dataplane"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[1])...)
+}
+
+func nodepoolId(id *common.ClusterNodepoolIdentifier) string {
+	return id.GetClusterName() + "/" + id.GetName()
+}
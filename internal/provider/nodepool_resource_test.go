@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/core"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc"
+)
+
+// mockNodepoolClient implements the subset of
+// cluster.ClusterNodepoolServiceClient used by the nodepools data source. Each
+// page holds the nodepools returned for one call.
+type mockNodepoolClient struct {
+	cluster.ClusterNodepoolServiceClient
+	pages [][]*cluster.Nodepool
+}
+
+func (m *mockNodepoolClient) ListNodepools(ctx context.Context, in *cluster.ListNodepoolsRequest, opts ...grpc.CallOption) (*cluster.ListNodepoolsResponse, error) {
+	page := 0
+	if in.GetRequest().GetToken() != "" {
+		page = int(in.GetRequest().GetToken()[0] - '0')
+	}
+	resp := &cluster.ListNodepoolsResponse{Nodepools: m.pages[page]}
+	if page+1 < len(m.pages) {
+		resp.Token = string(rune('0' + page + 1))
+	}
+	return resp, nil
+}
+
+func TestNodepoolResource_RoundTrip(t *testing.T) {
+	r := &NodepoolResource{org: "test-org"}
+	data := &NodepoolResourceModel{
+		Dataplane: types.StringValue("dp-1"),
+		Name:      types.StringValue("gpu"),
+		Direct: &NodepoolDirectModel{
+			Labels:      types.MapValueMust(types.StringType, map[string]attr.Value{"accelerator": types.StringValue("a100")}),
+			MinNodes:    types.Int64Null(),
+			MaxNodes:    types.Int64Value(4),
+			Allocatable: types.MapValueMust(types.StringType, map[string]attr.Value{"gpu": types.StringValue("8"), "memory": types.StringValue("640Gi")}),
+			Taints: []NodepoolTaintModel{{
+				Key:    types.StringValue("nvidia.com/gpu"),
+				Value:  types.StringNull(),
+				Effect: types.StringValue("no_schedule"),
+			}},
+		},
+	}
+
+	nodepool, err := r.nodepool(data)
+	if err != nil {
+		t.Fatalf("nodepool() returned error: %s", err)
+	}
+	direct := nodepool.GetDirect()
+	if direct.GetMaxNodes() != 4 || len(direct.GetAllocatable()) != 2 || direct.GetAllocatable()[0].GetName() != core.Resources_GPU {
+		t.Errorf("Unexpected direct spec: %v", direct)
+	}
+	if len(direct.GetTaints()) != 1 || direct.GetTaints()[0].GetEffect() != cluster.TaintEffect_NO_SCHEDULE {
+		t.Errorf("Expected a no_schedule taint, got %v", direct.GetTaints())
+	}
+
+	nodepool.Status = &cluster.NodepoolStatus{
+		Allocatable: &cluster.NodepoolAllocatable{
+			Observed: []*core.Resources_ResourceEntry{{Name: core.Resources_GPU, Value: "8"}},
+		},
+	}
+	refreshed := &NodepoolResourceModel{Direct: &NodepoolDirectModel{MinNodes: types.Int64Null()}}
+	if diags := r.refresh(context.Background(), refreshed, nodepool); diags.HasError() {
+		t.Fatalf("refresh() returned diagnostics: %v", diags)
+	}
+	if refreshed.Id.ValueString() != "dp-1/gpu" {
+		t.Errorf("Expected id dp-1/gpu, got %s", refreshed.Id)
+	}
+	if !refreshed.Direct.MinNodes.IsNull() || !refreshed.Direct.Allocatable.Equal(data.Direct.Allocatable) || refreshed.Direct.Taints[0] != data.Direct.Taints[0] {
+		t.Errorf("Direct spec drifted after refresh: %+v", refreshed.Direct)
+	}
+	observed := refreshed.Status.Attributes()["observed"].(types.Map)
+	if observed.Elements()["gpu"].(types.String).ValueString() != "8" {
+		t.Errorf("Expected the observed GPU count in the status, got %v", observed)
+	}
+
+	for _, effect := range []string{"evict", "NO_SCHEDULE"} {
+		data.Direct.Taints[0].Effect = types.StringValue(effect)
+		if _, err := r.nodepool(data); err == nil {
+			t.Errorf("Expected taint effect %q to be rejected", effect)
+		}
+	}
+}
+
+func TestNodepoolsDataSource_List(t *testing.T) {
+	d := &NodepoolsDataSource{
+		org: "test-org",
+		conn: &mockNodepoolClient{pages: [][]*cluster.Nodepool{
+			{{Id: &common.ClusterNodepoolIdentifier{ClusterName: "dp-1", Name: "cpu"}}},
+			{{Id: &common.ClusterNodepoolIdentifier{ClusterName: "dp-2", Name: "gpu"}}},
+		}},
+	}
+
+	nodepools, err := d.listNodepools(context.Background())
+	if err != nil {
+		t.Fatalf("listNodepools() returned error: %s", err)
+	}
+	if len(nodepools) != 2 || nodepools[1].GetId().GetName() != "gpu" {
+		t.Errorf("Expected nodepools from both pages, got %v", nodepools)
+	}
+}
@@ -0,0 +1,255 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/cluster"
+	"github.com/unionai/cloud/gen/pb-go/common"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &NodepoolsDataSource{}
+
+func NewNodepoolsDataSource() datasource.DataSource {
+	return &NodepoolsDataSource{}
+}
+
+// NodepoolsDataSource defines the data source implementation.
+type NodepoolsDataSource struct {
+	conn cluster.ClusterNodepoolServiceClient
+	org  string
+}
+
+// NodepoolsDataSourceModel describes the data source data model.
+type NodepoolsDataSourceModel struct {
+	Dataplane types.String              `tfsdk:"dataplane"`
+	Nodepools []NodepoolDataSourceModel `tfsdk:"nodepools"`
+}
+
+type NodepoolDataSourceModel struct {
+	Id                types.String `tfsdk:"id"`
+	Dataplane         types.String `tfsdk:"dataplane"`
+	Name              types.String `tfsdk:"name"`
+	Labels            types.Map    `tfsdk:"labels"`
+	MinNodes          types.Int64  `tfsdk:"min_nodes"`
+	MaxNodes          types.Int64  `tfsdk:"max_nodes"`
+	Allocatable       types.Map    `tfsdk:"allocatable"`
+	ReferenceProvider types.String `tfsdk:"reference_provider"`
+	ReferenceId       types.String `tfsdk:"reference_id"`
+	Status            types.Object `tfsdk:"status"`
+}
+
+func (d *NodepoolsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_nodepools"
+}
+
+func (d *NodepoolsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Nodepools data source",
+
+		Attributes: map[string]schema.Attribute{
+			"dataplane": schema.StringAttribute{
+				MarkdownDescription: "Only list the nodepools of this dataplane",
+				Optional:            true,
+			},
+			"nodepools": schema.ListNestedAttribute{
+				MarkdownDescription: "Nodepools",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Nodepool identifier, in the form `{dataplane}/{name}`",
+							Computed:            true,
+						},
+						"dataplane": schema.StringAttribute{
+							MarkdownDescription: "Dataplane the nodepool belongs to",
+							Computed:            true,
+						},
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Nodepool name",
+							Computed:            true,
+						},
+						"labels": schema.MapAttribute{
+							MarkdownDescription: "Labels of the nodes, for directly defined nodepools",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"min_nodes": schema.Int64Attribute{
+							MarkdownDescription: "Minimum number of nodes, for directly defined nodepools",
+							Computed:            true,
+						},
+						"max_nodes": schema.Int64Attribute{
+							MarkdownDescription: "Maximum number of nodes, for directly defined nodepools",
+							Computed:            true,
+						},
+						"allocatable": schema.MapAttribute{
+							MarkdownDescription: "Allocatable resources of a node, for directly defined nodepools",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+						"reference_provider": schema.StringAttribute{
+							MarkdownDescription: "Provider of the node group, for referenced nodepools",
+							Computed:            true,
+						},
+						"reference_id": schema.StringAttribute{
+							MarkdownDescription: "Identifier of the node group at the provider, for referenced nodepools",
+							Computed:            true,
+						},
+						"status": schema.SingleNestedAttribute{
+							MarkdownDescription: "Nodepool status as observed on the dataplane",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"labels": schema.MapAttribute{
+									MarkdownDescription: "Labels of the nodes",
+									Computed:            true,
+									ElementType:         types.StringType,
+								},
+								"taints": schema.ListNestedAttribute{
+									MarkdownDescription: "Taints of the nodes",
+									Computed:            true,
+									NestedObject: schema.NestedAttributeObject{
+										Attributes: map[string]schema.Attribute{
+											"key": schema.StringAttribute{
+												MarkdownDescription: "Taint key",
+												Computed:            true,
+											},
+											"value": schema.StringAttribute{
+												MarkdownDescription: "Taint value",
+												Computed:            true,
+											},
+											"effect": schema.StringAttribute{
+												MarkdownDescription: "Taint effect",
+												Computed:            true,
+											},
+										},
+									},
+								},
+								"projected": schema.MapAttribute{
+									MarkdownDescription: "Projected allocatable resources of a node",
+									Computed:            true,
+									ElementType:         types.StringType,
+								},
+								"observed": schema.MapAttribute{
+									MarkdownDescription: "Observed allocatable resources of a node",
+									Computed:            true,
+									ElementType:         types.StringType,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *NodepoolsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.conn = cluster.NewClusterNodepoolServiceClient(client.conn)
+	if d.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *cluster.ClusterNodepoolServiceClient, got: %T. Please report this issue to the provider developers.", d.conn),
+		)
+		return
+	}
+	d.org = client.org
+}
+
+// listNodepools pages through all nodepools of the organization.
+func (d *NodepoolsDataSource) listNodepools(ctx context.Context) ([]*cluster.Nodepool, error) {
+	var nodepools []*cluster.Nodepool
+	token := ""
+	for {
+		resp, err := d.conn.ListNodepools(ctx, &cluster.ListNodepoolsRequest{
+			Organization: d.org,
+			Request: &common.ListRequest{
+				Limit: 100,
+				Token: token,
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		nodepools = append(nodepools, resp.GetNodepools()...)
+		if resp.GetToken() == "" || len(resp.GetNodepools()) == 0 {
+			return nodepools, nil
+		}
+		token = resp.GetToken()
+	}
+}
+
+func (d *NodepoolsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data NodepoolsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nodepools, err := d.listNodepools(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Failed to fetch nodepools", err.Error())
+		return
+	}
+
+	data.Nodepools = []NodepoolDataSourceModel{}
+	for _, nodepool := range nodepools {
+		id := nodepool.GetId()
+		if !data.Dataplane.IsNull() && id.GetClusterName() != data.Dataplane.ValueString() {
+			continue
+		}
+
+		model := NodepoolDataSourceModel{
+			Id:                types.StringValue(nodepoolId(id)),
+			Dataplane:         types.StringValue(id.GetClusterName()),
+			Name:              types.StringValue(id.GetName()),
+			Labels:            types.MapNull(types.StringType),
+			MinNodes:          types.Int64Null(),
+			MaxNodes:          types.Int64Null(),
+			Allocatable:       types.MapNull(types.StringType),
+			ReferenceProvider: types.StringNull(),
+			ReferenceId:       types.StringNull(),
+		}
+		if direct := nodepool.GetDirect(); direct != nil {
+			model.Labels = convertStringsToMap(direct.GetLabels())
+			model.MinNodes = types.Int64Value(int64(direct.GetMinNodes()))
+			model.MaxNodes = types.Int64Value(int64(direct.GetMaxNodes()))
+			model.Allocatable = nodepoolResourceMap(direct.GetAllocatable())
+		}
+		if reference := nodepool.GetReference(); reference != nil {
+			model.ReferenceProvider = types.StringValue(reference.GetProvider())
+			model.ReferenceId = types.StringValue(reference.GetId())
+		}
+		statusValue, diags := nodepoolStatus(ctx, nodepool.GetStatus())
+		resp.Diagnostics.Append(diags...)
+		model.Status = statusValue
+
+		data.Nodepools = append(data.Nodepools, model)
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
@@ -0,0 +1,226 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"github.com/unionai/cloud/gen/pb-go/org"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &OrgSettingsResource{}
+var _ resource.ResourceWithImportState = &OrgSettingsResource{}
+
+func NewOrgSettingsResource() resource.Resource {
+	return &OrgSettingsResource{}
+}
+
+// OrgSettingsResource manages the settings of the provider's organization
+// through the OrgService. Only settings set in config are written, every
+// other setting is sent back as read.
+type OrgSettingsResource struct {
+	conn org.OrgServiceClient
+	org  string
+}
+
+// OrgSettingsResourceModel describes the resource data model.
+type OrgSettingsResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	DefaultPolicy types.String `tfsdk:"default_policy"`
+	LogoUrl       types.String `tfsdk:"logo_url"`
+}
+
+func (r *OrgSettingsResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_org_settings"
+}
+
+func (r *OrgSettingsResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Organization settings resource. Only one should exist per organization. Settings that are not set are left untouched.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Organization name.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"default_policy": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Policy assigned to every new user of the organization.",
+			},
+			"logo_url": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "URL of the logo displayed for the organization.",
+			},
+		},
+	}
+}
+
+func (r *OrgSettingsResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = org.NewOrgServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *org.OrgServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func (r *OrgSettingsResource) get(ctx context.Context) (*org.Org, error) {
+	got, err := r.conn.Get(ctx, &org.GetRequest{Id: &common.OrgIdentifier{Name: r.org}})
+	if err != nil {
+		return nil, err
+	}
+	return got.GetOrg(), nil
+}
+
+// apply writes the settings set in the model over the current organization,
+// leaving every other setting as read.
+func (r *OrgSettingsResource) apply(ctx context.Context, data *OrgSettingsResourceModel) (*org.Org, error) {
+	current, err := r.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if current.Info == nil {
+		current.Info = &org.Info{Id: &common.OrgIdentifier{Name: r.org}}
+	}
+	if !data.DefaultPolicy.IsNull() {
+		if current.Info.AuthorizationPreferences == nil {
+			current.Info.AuthorizationPreferences = &org.AuthorizationPreferences{}
+		}
+		current.Info.AuthorizationPreferences.DefaultPolicy = data.DefaultPolicy.ValueString()
+	}
+	if !data.LogoUrl.IsNull() {
+		if current.Info.DisplayPreferences == nil {
+			current.Info.DisplayPreferences = &org.DisplayPreferences{}
+		}
+		current.Info.DisplayPreferences.LogoUrl = data.LogoUrl.ValueString()
+	}
+
+	if _, err := r.conn.Update(ctx, &org.UpdateRequest{Org: current}); err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// refresh copies the managed settings of the organization into the model.
+// Settings that are not managed stay null.
+func (r *OrgSettingsResource) refresh(data *OrgSettingsResourceModel, o *org.Org) {
+	data.Id = types.StringValue(r.org)
+	if !data.DefaultPolicy.IsNull() {
+		data.DefaultPolicy = types.StringValue(o.GetInfo().GetAuthorizationPreferences().GetDefaultPolicy())
+	}
+	if !data.LogoUrl.IsNull() {
+		data.LogoUrl = types.StringValue(o.GetInfo().GetDisplayPreferences().GetLogoUrl())
+	}
+}
+
+func (r *OrgSettingsResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data OrgSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.apply(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update settings of organization %s, got error: %s", r.org, err))
+		return
+	}
+	r.refresh(&data, updated)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrgSettingsResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data OrgSettingsResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	current, err := r.get(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read settings of organization %s, got error: %s", r.org, err))
+		return
+	}
+	r.refresh(&data, current)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *OrgSettingsResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data OrgSettingsResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	updated, err := r.apply(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update settings of organization %s, got error: %s", r.org, err))
+		return
+	}
+	r.refresh(&data, updated)
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete only removes the settings from state. The organization keeps its
+// settings, as there is nothing to restore them to.
+func (r *OrgSettingsResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+}
+
+// ImportState accepts the organization name. Imported settings are only
+// managed once they are set in config.
+func (r *OrgSettingsResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	if req.ID != r.org {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected the organization of the provider, %q, got: %q", r.org, req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+}
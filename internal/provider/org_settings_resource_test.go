@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"github.com/unionai/cloud/gen/pb-go/org"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+)
+
+// mockOrgClient implements the subset of org.OrgServiceClient used by the org
+// settings resource.
+type mockOrgClient struct {
+	org.OrgServiceClient
+	org     *org.Org
+	updates []*org.UpdateRequest
+}
+
+func (m *mockOrgClient) Get(ctx context.Context, in *org.GetRequest, opts ...grpc.CallOption) (*org.GetResponse, error) {
+	return &org.GetResponse{Org: proto.Clone(m.org).(*org.Org)}, nil
+}
+
+func (m *mockOrgClient) Update(ctx context.Context, in *org.UpdateRequest, opts ...grpc.CallOption) (*org.UpdateResponse, error) {
+	m.updates = append(m.updates, in)
+	m.org = in.GetOrg()
+	return &org.UpdateResponse{}, nil
+}
+
+func TestOrgSettingsResource_OnlyManagesConfiguredSettings(t *testing.T) {
+	conn := &mockOrgClient{org: &org.Org{Info: &org.Info{
+		Id:                 &common.OrgIdentifier{Name: "test-org"},
+		DisplayPreferences: &org.DisplayPreferences{LogoUrl: "https://example.com/logo.png"},
+		Parent:             "parent-org",
+	}}}
+	r := &OrgSettingsResource{org: "test-org", conn: conn}
+
+	data := &OrgSettingsResourceModel{
+		DefaultPolicy: types.StringValue("viewer"),
+		LogoUrl:       types.StringNull(),
+	}
+	updated, err := r.apply(context.Background(), data)
+	if err != nil {
+		t.Fatalf("apply() returned error: %s", err)
+	}
+	info := conn.updates[0].GetOrg().GetInfo()
+	if info.GetAuthorizationPreferences().GetDefaultPolicy() != "viewer" {
+		t.Errorf("Expected the default policy to be written, got %v", info)
+	}
+	if info.GetDisplayPreferences().GetLogoUrl() != "https://example.com/logo.png" || info.GetParent() != "parent-org" {
+		t.Errorf("Expected unmanaged settings to be left untouched, got %v", info)
+	}
+
+	r.refresh(data, updated)
+	if data.Id.ValueString() != "test-org" || data.DefaultPolicy.ValueString() != "viewer" || !data.LogoUrl.IsNull() {
+		t.Errorf("Unexpected refreshed settings: %+v", data)
+	}
+
+	// Changes made outside of Terraform show up as drift
+	conn.org.Info.AuthorizationPreferences.DefaultPolicy = "admin"
+	current, err := r.get(context.Background())
+	if err != nil {
+		t.Fatalf("get() returned error: %s", err)
+	}
+	r.refresh(data, current)
+	if data.DefaultPolicy.ValueString() != "admin" {
+		t.Errorf("Expected the changed default policy, got %s", data.DefaultPolicy)
+	}
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/authorizer"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &PolicyMembersResource{}
+var _ resource.ResourceWithImportState = &PolicyMembersResource{}
+
+func NewPolicyMembersResource() resource.Resource {
+	return &PolicyMembersResource{}
+}
+
+// PolicyMembersResource owns the users and applications assigned to a policy.
+// In exclusive mode, principals assigned outside of Terraform are unassigned
+// on apply; otherwise only the configured principals are managed.
+type PolicyMembersResource struct {
+	conn authorizer.AuthorizerServiceClient
+	org  string
+}
+
+// PolicyMembersResourceModel describes the resource data model.
+type PolicyMembersResourceModel struct {
+	Id           types.String `tfsdk:"id"`
+	Policy       types.String `tfsdk:"policy"`
+	Users        types.Set    `tfsdk:"users"`
+	Applications types.Set    `tfsdk:"applications"`
+	Exclusive    types.Bool   `tfsdk:"exclusive"`
+}
+
+// policyMembers are the subjects of the users and applications assigned to a
+// policy.
+type policyMembers struct {
+	users        []string
+	applications []string
+}
+
+func (r *PolicyMembersResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_policy_members"
+}
+
+func (r *PolicyMembersResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Policy members resource. Owns the complete set of users and applications assigned to a policy.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Policy members identifier, the same as the policy.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"policy": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Policy identifier.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"users": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Subjects of the users assigned to the policy.",
+			},
+			"applications": schema.SetAttribute{
+				Optional:            true,
+				ElementType:         types.StringType,
+				MarkdownDescription: "Subjects of the applications assigned to the policy.",
+			},
+			"exclusive": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				Default:             booldefault.StaticBool(true),
+				MarkdownDescription: "Whether principals assigned to the policy outside of this resource are unassigned. When `false`, configured principals are only added. Defaults to `true`.",
+			},
+		},
+	}
+}
+
+func (r *PolicyMembersResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = authorizer.NewAuthorizerServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *authorizer.AuthorizerServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func userIdentity(subject string) *common.Identity {
+	return &common.Identity{
+		Principal: &common.Identity_UserId{
+			UserId: &common.UserIdentifier{
+				Subject: subject,
+			},
+		},
+	}
+}
+
+func applicationIdentity(subject string) *common.Identity {
+	return &common.Identity{
+		Principal: &common.Identity_ApplicationId{
+			ApplicationId: &common.ApplicationIdentifier{
+				Subject: subject,
+			},
+		},
+	}
+}
+
+// members lists the principals assigned to the policy. Assignments are listed
+// per identity, so every member of the organization is checked.
+func (r *PolicyMembersResource) members(ctx context.Context, policy string) (*policyMembers, error) {
+	listed, err := r.conn.ListMembers(ctx, &authorizer.ListMembersRequest{Organization: r.org})
+	if err != nil {
+		return nil, err
+	}
+	out := &policyMembers{}
+	if len(listed.GetMembers()) == 0 {
+		return out, nil
+	}
+
+	assignments, err := r.conn.ListIdentityAssignments(ctx, &authorizer.ListIdentityAssignmentsRequest{
+		Organization: r.org,
+		Identities:   listed.GetMembers(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range assignments.GetIdentityAssignments() {
+		if !slices.ContainsFunc(a.GetPolicies(), func(p *common.Policy) bool { return p.GetId().GetName() == policy }) {
+			continue
+		}
+		switch p := a.GetIdentity().GetPrincipal().(type) {
+		case *common.Identity_UserId:
+			out.users = append(out.users, p.UserId.GetSubject())
+		case *common.Identity_ApplicationId:
+			out.applications = append(out.applications, p.ApplicationId.GetSubject())
+		}
+	}
+	slices.Sort(out.users)
+	slices.Sort(out.applications)
+	return out, nil
+}
+
+func (r *PolicyMembersResource) assign(ctx context.Context, policy string, identity *common.Identity) error {
+	_, err := r.conn.AssignIdentity(ctx, &authorizer.AssignIdentityRequest{
+		Organization: r.org,
+		Identity:     identity,
+		Assignment: &authorizer.AssignIdentityRequest_PolicyId{
+			PolicyId: &common.PolicyIdentifier{
+				Name:         policy,
+				Organization: r.org,
+			},
+		},
+	})
+	return err
+}
+
+func (r *PolicyMembersResource) unassign(ctx context.Context, policy string, identity *common.Identity) error {
+	_, err := r.conn.UnassignIdentity(ctx, &authorizer.UnassignIdentityRequest{
+		Organization: r.org,
+		Identity:     identity,
+		Assignment: &authorizer.UnassignIdentityRequest_PolicyId{
+			PolicyId: &common.PolicyIdentifier{
+				Name:         policy,
+				Organization: r.org,
+			},
+		},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil
+	}
+	return err
+}
+
+// reconcile assigns the desired principals that are missing and unassigns the
+// stale ones: every other member when exclusive, otherwise only the ones that
+// were dropped from config since the last apply.
+func (r *PolicyMembersResource) reconcile(ctx context.Context, policy string, desired, current, prior []string, exclusive bool, identity func(string) *common.Identity) error {
+	for _, subject := range desired {
+		if slices.Contains(current, subject) {
+			continue
+		}
+		if err := r.assign(ctx, policy, identity(subject)); err != nil {
+			return fmt.Errorf("unable to assign %s: %w", subject, err)
+		}
+	}
+	stale := current
+	if !exclusive {
+		stale = prior
+	}
+	for _, subject := range stale {
+		if slices.Contains(desired, subject) || !slices.Contains(current, subject) {
+			continue
+		}
+		if err := r.unassign(ctx, policy, identity(subject)); err != nil {
+			return fmt.Errorf("unable to unassign %s: %w", subject, err)
+		}
+	}
+	return nil
+}
+
+// apply brings the members of the policy in line with plan. prior is nil on
+// create.
+func (r *PolicyMembersResource) apply(ctx context.Context, plan, prior *PolicyMembersResourceModel) error {
+	policy := plan.Policy.ValueString()
+	current, err := r.members(ctx, policy)
+	if err != nil {
+		return err
+	}
+	var priorUsers, priorApplications []string
+	if prior != nil {
+		priorUsers = convertSetToStrings(prior.Users)
+		priorApplications = convertSetToStrings(prior.Applications)
+	}
+	exclusive := plan.Exclusive.ValueBool()
+	if err := r.reconcile(ctx, policy, convertSetToStrings(plan.Users), current.users, priorUsers, exclusive, userIdentity); err != nil {
+		return err
+	}
+	return r.reconcile(ctx, policy, convertSetToStrings(plan.Applications), current.applications, priorApplications, exclusive, applicationIdentity)
+}
+
+// membersValue returns the members to store for an attribute. Exclusive
+// resources store every member so extra ones show as drift, others only the
+// configured members that are still assigned. Unset attributes stay null
+// while nothing needs to be shown.
+func membersValue(prior types.Set, actual []string, exclusive bool) types.Set {
+	if !exclusive {
+		kept := make([]string, 0, len(actual))
+		for _, subject := range convertSetToStrings(prior) {
+			if slices.Contains(actual, subject) {
+				kept = append(kept, subject)
+			}
+		}
+		actual = kept
+	}
+	if prior.IsNull() && len(actual) == 0 {
+		return prior
+	}
+	return convertStringsToSet(actual)
+}
+
+func (r *PolicyMembersResource) refresh(ctx context.Context, data *PolicyMembersResourceModel) error {
+	current, err := r.members(ctx, data.Policy.ValueString())
+	if err != nil {
+		return err
+	}
+	data.Id = data.Policy
+	data.Users = membersValue(data.Users, current.users, data.Exclusive.ValueBool())
+	data.Applications = membersValue(data.Applications, current.applications, data.Exclusive.ValueBool())
+	return nil
+}
+
+func (r *PolicyMembersResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PolicyMembersResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &data, nil); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set members of policy %s, got error: %s", data.Policy.ValueString(), err))
+		return
+	}
+	data.Id = data.Policy
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyMembersResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data PolicyMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.refresh(ctx, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read members of policy %s, got error: %s", data.Policy.ValueString(), err))
+		return
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *PolicyMembersResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data, state PolicyMembersResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.apply(ctx, &data, &state); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set members of policy %s, got error: %s", data.Policy.ValueString(), err))
+		return
+	}
+	data.Id = data.Policy
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// Delete unassigns the principals in state. Principals assigned outside of
+// Terraform since the last refresh are left assigned.
+func (r *PolicyMembersResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data PolicyMembersResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	policy := data.Policy.ValueString()
+	for _, subject := range convertSetToStrings(data.Users) {
+		if err := r.unassign(ctx, policy, userIdentity(subject)); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unassign user %s from policy %s, got error: %s", subject, policy, err))
+			return
+		}
+	}
+	for _, subject := range convertSetToStrings(data.Applications) {
+		if err := r.unassign(ctx, policy, applicationIdentity(subject)); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to unassign application %s from policy %s, got error: %s", subject, policy, err))
+			return
+		}
+	}
+}
+
+// ImportState accepts the policy identifier. Imported members are managed
+// exclusively.
+func (r *PolicyMembersResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("exclusive"), true)...)
+}
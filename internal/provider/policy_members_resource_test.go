@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/authorizer"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc"
+)
+
+// mockPolicyMembersClient implements the subset of AuthorizerServiceClient
+// used by the policy members resource. users and apps map each subject to the
+// policies it is assigned to.
+type mockPolicyMembersClient struct {
+	authorizer.AuthorizerServiceClient
+	users    map[string][]string
+	apps     map[string][]string
+	assigned []string
+	removed  []string
+}
+
+func (m *mockPolicyMembersClient) ListMembers(ctx context.Context, in *authorizer.ListMembersRequest, opts ...grpc.CallOption) (*authorizer.ListMembersResponse, error) {
+	var members []*common.Identity
+	for subject := range m.users {
+		members = append(members, userIdentity(subject))
+	}
+	for subject := range m.apps {
+		members = append(members, applicationIdentity(subject))
+	}
+	return &authorizer.ListMembersResponse{Members: members}, nil
+}
+
+func (m *mockPolicyMembersClient) ListIdentityAssignments(ctx context.Context, in *authorizer.ListIdentityAssignmentsRequest, opts ...grpc.CallOption) (*authorizer.ListIdentityAssignmentsResponse, error) {
+	var out []*authorizer.IdentityAssignment
+	for _, identity := range in.GetIdentities() {
+		var policies []string
+		if identity.GetUserId() != nil {
+			policies = m.users[identity.GetUserId().GetSubject()]
+		} else {
+			policies = m.apps[identity.GetApplicationId().GetSubject()]
+		}
+		assignment := &authorizer.IdentityAssignment{Identity: identity}
+		for _, p := range policies {
+			assignment.Policies = append(assignment.Policies, &common.Policy{Id: &common.PolicyIdentifier{Name: p}})
+		}
+		out = append(out, assignment)
+	}
+	return &authorizer.ListIdentityAssignmentsResponse{IdentityAssignments: out}, nil
+}
+
+func (m *mockPolicyMembersClient) AssignIdentity(ctx context.Context, in *authorizer.AssignIdentityRequest, opts ...grpc.CallOption) (*authorizer.AssignIdentityResponse, error) {
+	m.assigned = append(m.assigned, in.GetIdentity().GetUserId().GetSubject()+in.GetIdentity().GetApplicationId().GetSubject())
+	return &authorizer.AssignIdentityResponse{}, nil
+}
+
+func (m *mockPolicyMembersClient) UnassignIdentity(ctx context.Context, in *authorizer.UnassignIdentityRequest, opts ...grpc.CallOption) (*authorizer.UnassignIdentityResponse, error) {
+	m.removed = append(m.removed, in.GetIdentity().GetUserId().GetSubject()+in.GetIdentity().GetApplicationId().GetSubject())
+	return &authorizer.UnassignIdentityResponse{}, nil
+}
+
+func TestPolicyMembersResource_Apply(t *testing.T) {
+	newClient := func() *mockPolicyMembersClient {
+		return &mockPolicyMembersClient{
+			users: map[string][]string{"alice": {"admin"}, "bob": {"viewer"}, "carol": {"admin", "viewer"}},
+			apps:  map[string][]string{"ci": {"admin"}},
+		}
+	}
+	plan := &PolicyMembersResourceModel{
+		Policy:       types.StringValue("admin"),
+		Users:        convertStringsToSet([]string{"alice", "bob"}),
+		Applications: types.SetNull(types.StringType),
+		Exclusive:    types.BoolValue(true),
+	}
+
+	conn := newClient()
+	r := &PolicyMembersResource{conn: conn, org: "test-org"}
+	if err := r.apply(context.Background(), plan, nil); err != nil {
+		t.Fatalf("apply() returned error: %s", err)
+	}
+	slices.Sort(conn.removed)
+	if !slices.Equal(conn.assigned, []string{"bob"}) || !slices.Equal(conn.removed, []string{"carol", "ci"}) {
+		t.Errorf("Unexpected exclusive apply: assigned %v, removed %v", conn.assigned, conn.removed)
+	}
+
+	conn = newClient()
+	r.conn = conn
+	plan.Exclusive = types.BoolValue(false)
+	prior := &PolicyMembersResourceModel{Users: convertStringsToSet([]string{"alice", "carol"}), Applications: types.SetNull(types.StringType)}
+	if err := r.apply(context.Background(), plan, prior); err != nil {
+		t.Fatalf("apply() returned error: %s", err)
+	}
+	if !slices.Equal(conn.assigned, []string{"bob"}) || !slices.Equal(conn.removed, []string{"carol"}) {
+		t.Errorf("Expected only principals dropped from config to be removed: assigned %v, removed %v", conn.assigned, conn.removed)
+	}
+
+	r.conn = newClient()
+	if err := r.refresh(context.Background(), plan); err != nil {
+		t.Fatalf("refresh() returned error: %s", err)
+	}
+	if got := convertSetToStrings(plan.Users); !slices.Equal(got, []string{"alice"}) || !plan.Applications.IsNull() {
+		t.Errorf("Expected only configured members to be read when not exclusive, got %v and %v", got, plan.Applications)
+	}
+}
@@ -3,29 +3,24 @@ package provider
 import (
 	"context"
 	"fmt"
-	"slices"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/unionai/cloud/gen/pb-go/authorizer"
 	"github.com/unionai/cloud/gen/pb-go/common"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 )
 
-var validDomains = []string{
-	"production",
-	"staging",
-	"development",
-}
-
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &PolicyResource{}
 var _ resource.ResourceWithImportState = &PolicyResource{}
@@ -36,18 +31,25 @@ func NewPolicyResource() resource.Resource {
 
 // PolicyResource defines the resource implementation.
 type PolicyResource struct {
-	conn authorizer.AuthorizerServiceClient
-	org  string
+	conn    authorizer.AuthorizerServiceClient
+	org     string
+	domains []string
 }
 
 // PolicyResourceModel describes the resource data model.
 type PolicyResourceModel struct {
-	Id           types.String                `tfsdk:"id"`
-	Name         types.String                `tfsdk:"name"`
-	Description  types.String                `tfsdk:"description"`
-	Organization []PolicyRoleResourceOrg     `tfsdk:"organization"`
-	Project      []PolicyRoleResourceProject `tfsdk:"project"`
-	Domain       []PolicyRoleResourceDomain  `tfsdk:"domain"`
+	Id           types.String                   `tfsdk:"id"`
+	Name         types.String                   `tfsdk:"name"`
+	Description  types.String                   `tfsdk:"description"`
+	Organization []PolicyRoleResourceOrg        `tfsdk:"organization"`
+	Project      []PolicyRoleResourceProject    `tfsdk:"project"`
+	Domain       []PolicyRoleResourceDomain     `tfsdk:"domain"`
+	Workflow     []PolicyRoleResourceWorkflow   `tfsdk:"workflow"`
+	LaunchPlan   []PolicyRoleResourceLaunchPlan `tfsdk:"launch_plan"`
+	Cluster      []PolicyRoleResourceCluster    `tfsdk:"cluster"`
+	// UnmanagedBindings holds bindings to resource types the provider does
+	// not know, as JSON, so they are kept on update.
+	UnmanagedBindings types.List `tfsdk:"unmanaged_bindings"`
 }
 
 type PolicyRoleResourceOrg struct {
@@ -66,6 +68,25 @@ type PolicyRoleResourceDomain struct {
 	RoleId types.String `tfsdk:"role_id"`
 }
 
+type PolicyRoleResourceWorkflow struct {
+	Id      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	Domain  types.String `tfsdk:"domain"`
+	RoleId  types.String `tfsdk:"role_id"`
+}
+
+type PolicyRoleResourceLaunchPlan struct {
+	Id      types.String `tfsdk:"id"`
+	Project types.String `tfsdk:"project"`
+	Domain  types.String `tfsdk:"domain"`
+	RoleId  types.String `tfsdk:"role_id"`
+}
+
+type PolicyRoleResourceCluster struct {
+	Id     types.String `tfsdk:"id"`
+	RoleId types.String `tfsdk:"role_id"`
+}
+
 func (r *PolicyResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_policy"
 }
@@ -87,14 +108,22 @@ func (r *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 				MarkdownDescription: "Policy name",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
 				},
 			},
 			"description": schema.StringAttribute{
-				MarkdownDescription: "Policy description",
+				MarkdownDescription: "Policy description. The API cannot update a policy, so changing the description replaces it, and users and applications assigned to the policy lose their assignment",
 				Optional:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"unmanaged_bindings": schema.ListAttribute{
+				MarkdownDescription: "Bindings to resource types not supported by the provider, as JSON. They are left in place on update",
+				Computed:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
 				},
 			},
 		},
@@ -107,22 +136,13 @@ func (r *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						"id": schema.StringAttribute{
 							MarkdownDescription: "Organization ID",
 							Required:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 						"role_id": schema.StringAttribute{
 							MarkdownDescription: "Role ID",
 							Required:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 					},
 				},
-				PlanModifiers: []planmodifier.Set{
-					setplanmodifier.RequiresReplace(),
-				},
 			},
 			"project": schema.SetNestedBlock{
 				MarkdownDescription: "Project configuration",
@@ -131,30 +151,18 @@ func (r *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						"id": schema.StringAttribute{
 							MarkdownDescription: "Project ID",
 							Required:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 						"domains": schema.SetAttribute{
 							MarkdownDescription: "Domain IDs",
 							Required:            true,
 							ElementType:         types.StringType,
-							PlanModifiers: []planmodifier.Set{
-								setplanmodifier.RequiresReplace(),
-							},
 						},
 						"role_id": schema.StringAttribute{
 							MarkdownDescription: "Role ID",
 							Required:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 					},
 				},
-				PlanModifiers: []planmodifier.Set{
-					setplanmodifier.RequiresReplace(),
-				},
 			},
 			"domain": schema.SetNestedBlock{
 				MarkdownDescription: "Domain configuration",
@@ -163,21 +171,73 @@ func (r *PolicyResource) Schema(ctx context.Context, req resource.SchemaRequest,
 						"id": schema.StringAttribute{
 							MarkdownDescription: "Domain ID",
 							Required:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 						"role_id": schema.StringAttribute{
 							MarkdownDescription: "Role ID",
 							Required:            true,
-							PlanModifiers: []planmodifier.String{
-								stringplanmodifier.RequiresReplace(),
-							},
 						},
 					},
 				},
-				PlanModifiers: []planmodifier.Set{
-					setplanmodifier.RequiresReplace(),
+			},
+			"workflow": schema.SetNestedBlock{
+				MarkdownDescription: "Workflow configuration",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Workflow name",
+							Required:            true,
+						},
+						"project": schema.StringAttribute{
+							MarkdownDescription: "Project ID",
+							Required:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "Domain ID",
+							Required:            true,
+						},
+						"role_id": schema.StringAttribute{
+							MarkdownDescription: "Role ID",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"launch_plan": schema.SetNestedBlock{
+				MarkdownDescription: "Launch plan configuration",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Launch plan name",
+							Required:            true,
+						},
+						"project": schema.StringAttribute{
+							MarkdownDescription: "Project ID",
+							Required:            true,
+						},
+						"domain": schema.StringAttribute{
+							MarkdownDescription: "Domain ID",
+							Required:            true,
+						},
+						"role_id": schema.StringAttribute{
+							MarkdownDescription: "Role ID",
+							Required:            true,
+						},
+					},
+				},
+			},
+			"cluster": schema.SetNestedBlock{
+				MarkdownDescription: "Cluster configuration",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.StringAttribute{
+							MarkdownDescription: "Cluster name",
+							Required:            true,
+						},
+						"role_id": schema.StringAttribute{
+							MarkdownDescription: "Role ID",
+							Required:            true,
+						},
+					},
 				},
 			},
 		},
@@ -210,18 +270,11 @@ func (r *PolicyResource) Configure(ctx context.Context, req resource.ConfigureRe
 		return
 	}
 	r.org = client.org
+	r.domains = domainIds(client.domains)
 }
 
-func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
-	var data PolicyResourceModel
-
-	// Read Terraform plan data into the model
-	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
-
-	if resp.Diagnostics.HasError() {
-		return
-	}
-
+// bindings converts the blocks of the model into policy bindings.
+func (r *PolicyResource) bindings(data *PolicyResourceModel) ([]*common.PolicyBinding, error) {
 	bindings := make([]*common.PolicyBinding, 0)
 
 	for _, org := range data.Organization {
@@ -261,10 +314,8 @@ func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	for _, project := range data.Project {
 		for _, domain := range project.Domains.Elements() {
-			if !slices.Contains(validDomains, domain.(types.String).ValueString()) {
-				resp.Diagnostics.AddError("Invalid Domain",
-					fmt.Sprintf("Domain %s is not valid. Must be one of %v", domain.(types.String).ValueString(), validDomains))
-				return
+			if err := validateDomain(r.domains, domain.(types.String).ValueString()); err != nil {
+				return nil, err
 			}
 			bindings = append(bindings, &common.PolicyBinding{
 				RoleId: &common.RoleIdentifier{
@@ -288,13 +339,227 @@ func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	for _, workflow := range data.Workflow {
+		project, err := r.project(workflow.Project, workflow.Domain)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, &common.PolicyBinding{
+			RoleId: &common.RoleIdentifier{
+				Name:         workflow.RoleId.ValueString(),
+				Organization: r.org,
+			},
+			Resource: &common.Resource{
+				Resource: &common.Resource_Workflow{
+					Workflow: &common.Workflow{
+						Name:    workflow.Id.ValueString(),
+						Project: project,
+					},
+				},
+			},
+		})
+	}
+
+	for _, launchPlan := range data.LaunchPlan {
+		project, err := r.project(launchPlan.Project, launchPlan.Domain)
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, &common.PolicyBinding{
+			RoleId: &common.RoleIdentifier{
+				Name:         launchPlan.RoleId.ValueString(),
+				Organization: r.org,
+			},
+			Resource: &common.Resource{
+				Resource: &common.Resource_LaunchPlan{
+					LaunchPlan: &common.LaunchPlan{
+						Name:    launchPlan.Id.ValueString(),
+						Project: project,
+					},
+				},
+			},
+		})
+	}
+
+	for _, c := range data.Cluster {
+		bindings = append(bindings, &common.PolicyBinding{
+			RoleId: &common.RoleIdentifier{
+				Name:         c.RoleId.ValueString(),
+				Organization: r.org,
+			},
+			Resource: &common.Resource{
+				Resource: &common.Resource_Cluster{
+					Cluster: &common.ClusterIdentifier{
+						Name:         c.Id.ValueString(),
+						Organization: r.org,
+					},
+				},
+			},
+		})
+	}
+
+	return bindings, nil
+}
+
+// project builds the project a workflow or launch plan binding is scoped to.
+func (r *PolicyResource) project(name, domain types.String) (*common.Project, error) {
+	if err := validateDomain(r.domains, domain.ValueString()); err != nil {
+		return nil, err
+	}
+	return &common.Project{
+		Name: name.ValueString(),
+		Domain: &common.Domain{
+			Name: domain.ValueString(),
+			Organization: &common.Organization{
+				Name: r.org,
+			},
+		},
+	}, nil
+}
+
+// unmanagedBinding reports whether a binding is to a resource type the
+// provider does not map to a block.
+func unmanagedBinding(b *common.PolicyBinding) bool {
+	switch b.GetResource().GetResource().(type) {
+	case *common.Resource_Organization, *common.Resource_Domain, *common.Resource_Project,
+		*common.Resource_Workflow, *common.Resource_LaunchPlan, *common.Resource_Cluster:
+		return false
+	}
+	return true
+}
+
+// unmanagedBindingsValue renders the unmanaged bindings as a list of JSON
+// documents.
+func unmanagedBindingsValue(bindings []*common.PolicyBinding) types.List {
+	values := make([]attr.Value, 0)
+	for _, b := range bindings {
+		if !unmanagedBinding(b) {
+			continue
+		}
+		doc, err := protojson.Marshal(b)
+		if err != nil {
+			doc = []byte(b.String())
+		}
+		values = append(values, types.StringValue(string(doc)))
+	}
+	return types.ListValueMust(types.StringType, values)
+}
+
+// policyBindingKey identifies a binding by its role and the names of the
+// resource it is bound to, ignoring fields the service may fill in on read.
+// Bindings of other resource types are compared as a whole.
+func policyBindingKey(b *common.PolicyBinding) string {
+	role := b.GetRoleId().GetName()
+	switch res := b.GetResource().GetResource().(type) {
+	case *common.Resource_Organization:
+		return strings.Join([]string{role, "organization", res.Organization.GetName()}, "|")
+	case *common.Resource_Domain:
+		return strings.Join([]string{role, "domain", res.Domain.GetName()}, "|")
+	case *common.Resource_Project:
+		return strings.Join([]string{role, "project", res.Project.GetName(), res.Project.GetDomain().GetName()}, "|")
+	case *common.Resource_Workflow:
+		return strings.Join([]string{role, "workflow", res.Workflow.GetName(), res.Workflow.GetProject().GetName(), res.Workflow.GetProject().GetDomain().GetName()}, "|")
+	case *common.Resource_LaunchPlan:
+		return strings.Join([]string{role, "launch_plan", res.LaunchPlan.GetName(), res.LaunchPlan.GetProject().GetName(), res.LaunchPlan.GetProject().GetDomain().GetName()}, "|")
+	case *common.Resource_Cluster:
+		return strings.Join([]string{role, "cluster", res.Cluster.GetName()}, "|")
+	}
+	key, _ := proto.MarshalOptions{Deterministic: true}.Marshal(b)
+	return string(key)
+}
+
+// diffPolicyBindings returns the bindings of desired missing from current,
+// and the bindings of current missing from desired.
+func diffPolicyBindings(current, desired []*common.PolicyBinding) (added, removed []*common.PolicyBinding) {
+	have := make(map[string]bool, len(current))
+	for _, b := range current {
+		have[policyBindingKey(b)] = true
+	}
+	want := make(map[string]bool, len(desired))
+	for _, b := range desired {
+		key := policyBindingKey(b)
+		if want[key] {
+			continue
+		}
+		want[key] = true
+		if !have[key] {
+			added = append(added, b)
+		}
+	}
+	for _, b := range current {
+		if !want[policyBindingKey(b)] {
+			removed = append(removed, b)
+		}
+	}
+	return added, removed
+}
+
+// update applies the difference between the remote policy and the plan in
+// place. Bindings are added before any are removed, so subjects assigned to
+// the policy keep the access that is not changing. Bindings to resource types
+// the provider does not know are kept as they are. The AuthorizerService has
+// no call to update a policy, so a description change replaces the policy.
+func (r *PolicyResource) update(ctx context.Context, plan *PolicyResourceModel) ([]*common.PolicyBinding, error) {
+	desired, err := r.bindings(plan)
+	if err != nil {
+		return nil, err
+	}
+	if len(desired) == 0 {
+		return nil, fmt.Errorf("policy must have at least one resource scope (organization, project, domain, workflow, launch_plan, or cluster)")
+	}
+
+	id := &common.PolicyIdentifier{
+		Name:         plan.Id.ValueString(),
+		Organization: r.org,
+	}
+	policy, err := r.conn.GetPolicy(ctx, &authorizer.GetPolicyRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, b := range policy.GetPolicy().GetBindings() {
+		if unmanagedBinding(b) {
+			desired = append(desired, b)
+		}
+	}
+
+	added, removed := diffPolicyBindings(policy.GetPolicy().GetBindings(), desired)
+	for _, b := range added {
+		if _, err := r.conn.CreatePolicyBinding(ctx, &authorizer.CreatePolicyBindingRequest{PolicyId: id, Binding: b}); err != nil {
+			return nil, fmt.Errorf("unable to add binding %v: %w", b, err)
+		}
+	}
+	for _, b := range removed {
+		if _, err := r.conn.DeletePolicyBinding(ctx, &authorizer.DeletePolicyBindingRequest{PolicyId: id, Binding: b}); err != nil {
+			return nil, fmt.Errorf("unable to remove binding %v: %w", b, err)
+		}
+	}
+	return desired, nil
+}
+
+func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data PolicyResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	bindings, err := r.bindings(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Domain", err.Error())
+		return
+	}
+
 	if len(bindings) == 0 {
 		resp.Diagnostics.AddError("Invalid Resource Scope",
-			"Policy must have at least one resource scope (organization, project, or domain).")
+			"Policy must have at least one resource scope (organization, project, domain, workflow, launch_plan, or cluster).")
 		return
 	}
 
-	if _, err := r.conn.CreatePolicy(ctx, &authorizer.CreatePolicyRequest{
+	if _, err = r.conn.CreatePolicy(ctx, &authorizer.CreatePolicyRequest{
 		Policy: &common.Policy{
 			Id: &common.PolicyIdentifier{
 				Name:         data.Name.ValueString(),
@@ -322,6 +587,7 @@ func (r *PolicyResource) Create(ctx context.Context, req resource.CreateRequest,
 	}
 
 	data.Id = types.StringValue(data.Name.ValueString())
+	data.UnmanagedBindings = unmanagedBindingsValue(nil)
 
 	// Save data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
@@ -369,11 +635,14 @@ func (r *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	// Reconstruct nested blocks from bindings
 	orgs := make([]PolicyRoleResourceOrg, 0)
 	domains := make([]PolicyRoleResourceDomain, 0)
+	workflows := make([]PolicyRoleResourceWorkflow, 0)
+	launchPlans := make([]PolicyRoleResourceLaunchPlan, 0)
+	clusters := make([]PolicyRoleResourceCluster, 0)
 	projDomains := make(map[string][]string) // key: roleId|projectId -> []domain
 
 	for _, b := range policy.Policy.Bindings {
 		roleId := b.RoleId.GetName()
-		switch res := b.Resource.Resource.(type) {
+		switch res := b.GetResource().GetResource().(type) {
 		case *common.Resource_Organization:
 			orgs = append(orgs, PolicyRoleResourceOrg{
 				Id:     types.StringValue(res.Organization.GetName()),
@@ -397,6 +666,25 @@ func (r *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 				// track project even if no domain present
 				projDomains[key] = []string{}
 			}
+		case *common.Resource_Workflow:
+			workflows = append(workflows, PolicyRoleResourceWorkflow{
+				Id:      types.StringValue(res.Workflow.GetName()),
+				Project: types.StringValue(res.Workflow.GetProject().GetName()),
+				Domain:  types.StringValue(res.Workflow.GetProject().GetDomain().GetName()),
+				RoleId:  types.StringValue(roleId),
+			})
+		case *common.Resource_LaunchPlan:
+			launchPlans = append(launchPlans, PolicyRoleResourceLaunchPlan{
+				Id:      types.StringValue(res.LaunchPlan.GetName()),
+				Project: types.StringValue(res.LaunchPlan.GetProject().GetName()),
+				Domain:  types.StringValue(res.LaunchPlan.GetProject().GetDomain().GetName()),
+				RoleId:  types.StringValue(roleId),
+			})
+		case *common.Resource_Cluster:
+			clusters = append(clusters, PolicyRoleResourceCluster{
+				Id:     types.StringValue(res.Cluster.GetName()),
+				RoleId: types.StringValue(roleId),
+			})
 		}
 	}
 
@@ -431,21 +719,34 @@ func (r *PolicyResource) Read(ctx context.Context, req resource.ReadRequest, res
 	data.Organization = orgs
 	data.Domain = domains
 	data.Project = projects
+	data.Workflow = workflows
+	data.LaunchPlan = launchPlans
+	data.Cluster = clusters
+	data.UnmanagedBindings = unmanagedBindingsValue(policy.Policy.Bindings)
 
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
 func (r *PolicyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data PolicyResourceModel
+	var data, state PolicyResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.Id = state.Id
+	bindings, err := r.update(ctx, &data)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update policy %s, got error: %s", data.Id.ValueString(), err))
+		return
+	}
+	data.UnmanagedBindings = unmanagedBindingsValue(bindings)
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
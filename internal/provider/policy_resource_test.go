@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/authorizer"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"google.golang.org/grpc"
+)
+
+// mockPolicyClient implements the subset of AuthorizerServiceClient used to
+// update policies, recording the calls in order.
+type mockPolicyClient struct {
+	authorizer.AuthorizerServiceClient
+	policy *common.Policy
+	calls  []string
+}
+
+func (m *mockPolicyClient) GetPolicy(ctx context.Context, in *authorizer.GetPolicyRequest, opts ...grpc.CallOption) (*authorizer.GetPolicyResponse, error) {
+	return &authorizer.GetPolicyResponse{Policy: m.policy}, nil
+}
+
+func (m *mockPolicyClient) CreatePolicyBinding(ctx context.Context, in *authorizer.CreatePolicyBindingRequest, opts ...grpc.CallOption) (*authorizer.CreatePolicyBindingResponse, error) {
+	m.calls = append(m.calls, "add:"+policyBindingKey(in.GetBinding()))
+	return &authorizer.CreatePolicyBindingResponse{}, nil
+}
+
+func (m *mockPolicyClient) DeletePolicyBinding(ctx context.Context, in *authorizer.DeletePolicyBindingRequest, opts ...grpc.CallOption) (*authorizer.DeletePolicyBindingResponse, error) {
+	m.calls = append(m.calls, "remove:"+policyBindingKey(in.GetBinding()))
+	return &authorizer.DeletePolicyBindingResponse{}, nil
+}
+
+func policyProjectModel(domains ...string) PolicyRoleResourceProject {
+	values := make([]attr.Value, len(domains))
+	for i, d := range domains {
+		values[i] = types.StringValue(d)
+	}
+	return PolicyRoleResourceProject{
+		Id:      types.StringValue("ml"),
+		Domains: types.SetValueMust(types.StringType, values),
+		RoleId:  types.StringValue("developer"),
+	}
+}
+
+func TestPolicyResource_Update(t *testing.T) {
+	r := &PolicyResource{org: "test-org", domains: defaultDomains}
+	prior := &PolicyResourceModel{
+		Id:          types.StringValue("ml-dev"),
+		Description: types.StringValue("old"),
+		Project:     []PolicyRoleResourceProject{policyProjectModel("development", "staging")},
+	}
+	current, err := r.bindings(prior)
+	if err != nil {
+		t.Fatalf("bindings() returned error: %s", err)
+	}
+	// The service fills in the organization of bound resources on read.
+	current[0].GetResource().GetProject().GetDomain().GetOrganization().Name = ""
+	conn := &mockPolicyClient{policy: &common.Policy{Bindings: current}}
+	r.conn = conn
+
+	plan := &PolicyResourceModel{
+		Id:          types.StringValue("ml-dev"),
+		Description: types.StringValue("old"),
+		Project:     []PolicyRoleResourceProject{policyProjectModel("staging", "production")},
+		Domain:      []PolicyRoleResourceDomain{{Id: types.StringValue("production"), RoleId: types.StringValue("viewer")}},
+	}
+	if _, err := r.update(context.Background(), plan); err != nil {
+		t.Fatalf("update() returned error: %s", err)
+	}
+	expected := []string{
+		"add:viewer|domain|production",
+		"add:developer|project|ml|production",
+		"remove:developer|project|ml|development",
+	}
+	if len(conn.calls) != len(expected) {
+		t.Fatalf("Expected calls %v, got %v", expected, conn.calls)
+	}
+	for i := range expected {
+		if conn.calls[i] != expected[i] {
+			t.Errorf("Expected calls %v, got %v", expected, conn.calls)
+		}
+	}
+
+	plan.Project = []PolicyRoleResourceProject{policyProjectModel("testing")}
+	if _, err := r.update(context.Background(), plan); err == nil {
+		t.Error("Expected an invalid domain to be rejected")
+	}
+}
+
+func TestPolicyResource_UnmanagedBindings(t *testing.T) {
+	unknown := &common.PolicyBinding{RoleId: &common.RoleIdentifier{Name: "admin"}, Resource: &common.Resource{}}
+	cluster := &common.PolicyBinding{
+		RoleId:   &common.RoleIdentifier{Name: "admin"},
+		Resource: &common.Resource{Resource: &common.Resource_Cluster{Cluster: &common.ClusterIdentifier{Name: "gpu"}}},
+	}
+	conn := &mockPolicyClient{policy: &common.Policy{Bindings: []*common.PolicyBinding{unknown, cluster}}}
+	r := &PolicyResource{conn: conn, org: "test-org", domains: []string{"production", "qa"}}
+
+	plan := &PolicyResourceModel{
+		Id: types.StringValue("runner"),
+		LaunchPlan: []PolicyRoleResourceLaunchPlan{{
+			Id:      types.StringValue("nightly"),
+			Project: types.StringValue("ml"),
+			Domain:  types.StringValue("qa"),
+			RoleId:  types.StringValue("executor"),
+		}},
+	}
+	bindings, err := r.update(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("update() returned error: %s", err)
+	}
+	expected := []string{"add:executor|launch_plan|nightly|ml|qa", "remove:admin|cluster|gpu"}
+	if len(conn.calls) != len(expected) || conn.calls[0] != expected[0] || conn.calls[1] != expected[1] {
+		t.Errorf("Expected calls %v, got %v", expected, conn.calls)
+	}
+	if unmanaged := unmanagedBindingsValue(bindings); len(unmanaged.Elements()) != 1 {
+		t.Errorf("Expected the unknown binding to be kept, got %v", unmanaged)
+	}
+}
@@ -31,7 +31,8 @@ func NewProjectDomainAttributesResource() resource.Resource {
 // Flyte renders per project-domain namespace — most commonly to set a
 // per-project IAM role via the defaultUserRoleValue template variable.
 type ProjectDomainAttributesResource struct {
-	conn service.AdminServiceClient
+	conn    service.AdminServiceClient
+	domains []string
 }
 
 // ProjectDomainAttributesResourceModel describes the resource data model.
@@ -68,7 +69,7 @@ func (r *ProjectDomainAttributesResource) Schema(ctx context.Context, req resour
 				},
 			},
 			"domain": schema.StringAttribute{
-				MarkdownDescription: "Domain the attributes apply to (e.g. `development`, `staging`, `production`).",
+				MarkdownDescription: "Domain the attributes apply to (e.g. `development`, `staging`, `production`). Must be one of the domains of the organization.",
 				Required:            true,
 				PlanModifiers: []planmodifier.String{
 					stringplanmodifier.RequiresReplace(),
@@ -109,6 +110,7 @@ func (r *ProjectDomainAttributesResource) Configure(ctx context.Context, req res
 		)
 		return
 	}
+	r.domains = domainIds(client.domains)
 }
 
 // upsert applies the attribute map to the project-domain via the matchable
@@ -145,6 +147,11 @@ func (r *ProjectDomainAttributesResource) Create(ctx context.Context, req resour
 		return
 	}
 
+	if err := validateDomain(r.domains, data.Domain.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Invalid Domain", err.Error())
+		return
+	}
+
 	if err := r.upsert(ctx, &data); err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to set project-domain attributes, got error: %s", err))
 		return
@@ -125,6 +125,7 @@ func TestProjectDomainAttributesResource_Create(t *testing.T) {
 				return &admin.ProjectDomainAttributesUpdateResponse{}, nil
 			},
 		},
+		domains: defaultDomains,
 	}
 
 	resp := &resource.CreateResponse{State: tfsdk.State{Schema: projectDomainAttributesSchema(t).Schema}}
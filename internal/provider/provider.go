@@ -3,8 +3,11 @@ package provider
 import (
 	"context"
 	"crypto/tls"
+	"fmt"
 	"os"
 
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/admin"
+	"github.com/flyteorg/flyte/flyteidl/gen/pb-go/flyteidl/service"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -24,15 +27,30 @@ type UnionaiProvider struct {
 
 // UnionaiProviderModel describes the provider data model.
 type UnionaiProviderModel struct {
-	ApiKey      types.String `tfsdk:"api_key"`
-	Org         types.String `tfsdk:"org"`
-	AllowedOrgs types.Set    `tfsdk:"allowed_orgs"`
+	ApiKey      types.String   `tfsdk:"api_key"`
+	Org         types.String   `tfsdk:"org"`
+	AllowedOrgs types.Set      `tfsdk:"allowed_orgs"`
+	FlyteCLI    *FlyteCLIModel `tfsdk:"flyte_cli"`
+}
+
+// FlyteCLIModel describes how the flyte CLI is run, on the provider and on
+// resources that shell out to it.
+type FlyteCLIModel struct {
+	Path       types.String `tfsdk:"path"`
+	Env        types.Map    `tfsdk:"env"`
+	WorkingDir types.String `tfsdk:"working_dir"`
+	Timeout    types.String `tfsdk:"timeout"`
 }
 
 type providerContext struct {
-	conn *grpc.ClientConn
-	org  string
-	host string
+	conn     *grpc.ClientConn
+	org      string
+	host     string
+	flyteCLI FlyteCLIConfig
+	// domains are the domains of the organization, read once at configure
+	// time. domainsErr is set when they could not be read.
+	domains    []*admin.Domain
+	domainsErr error
 }
 
 func (p *UnionaiProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
@@ -56,6 +74,29 @@ func (p *UnionaiProvider) Schema(ctx context.Context, req provider.SchemaRequest
 				Optional:            true, // they can be specified by UNIONAI_ALLOWED_ORGS
 				ElementType:         types.StringType,
 			},
+			"flyte_cli": schema.SingleNestedAttribute{
+				MarkdownDescription: "Settings for running the flyte CLI, used by `unionai_task_environment` resources deployed from a Python file. The CLI authenticates with the provider API key.",
+				Optional:            true,
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						MarkdownDescription: "Path of the flyte binary, e.g. inside a pinned virtualenv. Defaults to `flyte` looked up in `PATH`.",
+						Optional:            true,
+					},
+					"env": schema.MapAttribute{
+						MarkdownDescription: "Extra environment variables for the CLI.",
+						Optional:            true,
+						ElementType:         types.StringType,
+					},
+					"working_dir": schema.StringAttribute{
+						MarkdownDescription: "Directory the CLI runs in. Relative task paths are resolved against it. Defaults to the Terraform working directory.",
+						Optional:            true,
+					},
+					"timeout": schema.StringAttribute{
+						MarkdownDescription: "Maximum duration of each CLI invocation, e.g. `15m`. Defaults to `10m`.",
+						Optional:            true,
+					},
+				},
+			},
 		},
 	}
 }
@@ -102,13 +143,18 @@ func (p *UnionaiProvider) Configure(ctx context.Context, req provider.ConfigureR
 		return
 	}
 
+	flyteCLI, err := FlyteCLIConfig{ApiKey: apiKey}.withOverrides(data.FlyteCLI)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid flyte_cli settings", err.Error())
+		return
+	}
+
 	client := &providerContext{
-		conn: conn,
-		org:  apiTokenConfig.Org,
-		host: apiTokenConfig.Host,
+		conn:     conn,
+		org:      apiTokenConfig.Org,
+		host:     apiTokenConfig.Host,
+		flyteCLI: flyteCLI,
 	}
-	resp.DataSourceData = client
-	resp.ResourceData = client
 
 	if len(data.AllowedOrgs.Elements()) > 0 {
 		// Check if our org is allowed
@@ -127,6 +173,16 @@ func (p *UnionaiProvider) Configure(ctx context.Context, req provider.ConfigureR
 			return
 		}
 	}
+
+	client.domains, client.domainsErr = fetchDomains(ctx, service.NewAdminServiceClient(conn))
+	if client.domainsErr != nil {
+		resp.Diagnostics.AddWarning(
+			"Unable to read Union.ai domains",
+			fmt.Sprintf("Domains are validated against the default domains %v. Got error: %s", defaultDomains, client.domainsErr),
+		)
+	}
+	resp.DataSourceData = client
+	resp.ResourceData = client
 }
 
 func (p *UnionaiProvider) Resources(ctx context.Context) []func() resource.Resource {
@@ -141,6 +197,14 @@ func (p *UnionaiProvider) Resources(ctx context.Context) []func() resource.Resou
 		NewAppAccessResource,
 		NewTaskEnvironmentResource,
 		NewProjectDomainAttributesResource,
+		NewSecretResource,
+		NewTriggerResource,
+		NewServingAppResource,
+		NewNodepoolResource,
+		NewManagedClusterResource,
+		NewDataplaneResource,
+		NewOrgSettingsResource,
+		NewPolicyMembersResource,
 	}
 }
 
@@ -157,6 +221,13 @@ func (p *UnionaiProvider) DataSources(ctx context.Context) []func() datasource.D
 		NewDataplaneDataSource,
 		NewDataplanesDataSource,
 		NewControlplaneDataSource,
+		NewTriggerRevisionsDataSource,
+		NewNodepoolsDataSource,
+		NewClusterPoolDataSource,
+		NewDataplanePoolsDataSource,
+		NewManagedClustersDataSource,
+		NewDomainsDataSource,
+		NewAuthorizationCheckDataSource,
 	}
 }
 
@@ -10,12 +10,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
-	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/unionai/cloud/gen/pb-go/authorizer"
 	"github.com/unionai/cloud/gen/pb-go/common"
+	"github.com/unionai/cloud/gen/pb-go/identity"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -28,10 +28,13 @@ func NewRoleResource() resource.Resource {
 	return &RoleResource{}
 }
 
-// RoleResource defines the resource implementation.
+// RoleResource defines the resource implementation. Roles are created, read
+// and deleted through the AuthorizerService and updated in place through the
+// RoleService.
 type RoleResource struct {
-	conn authorizer.AuthorizerServiceClient
-	org  string
+	conn  authorizer.AuthorizerServiceClient
+	roles identity.RoleServiceClient
+	org   string
 }
 
 // RoleResourceModel describes the resource data model.
@@ -69,17 +72,11 @@ func (r *RoleResource) Schema(ctx context.Context, req resource.SchemaRequest, r
 			"description": schema.StringAttribute{
 				MarkdownDescription: "Role description",
 				Optional:            true,
-				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
-				},
 			},
 			"actions": schema.SetAttribute{
 				MarkdownDescription: "Policy actions",
 				Required:            true,
 				ElementType:         types.StringType,
-				PlanModifiers: []planmodifier.Set{
-					setplanmodifier.RequiresReplace(),
-				},
 			},
 		},
 	}
@@ -110,9 +107,110 @@ func (r *RoleResource) Configure(ctx context.Context, req resource.ConfigureRequ
 		)
 		return
 	}
+	r.roles = identity.NewRoleServiceClient(client.conn)
+	if r.roles == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *identity.RoleServiceClient, got: %T. Please report this issue to the provider developers.", r.roles),
+		)
+		return
+	}
 	r.org = client.org
 }
 
+// roleActions converts action names, such as view_flyte_inventory, into
+// their proto values.
+func roleActions(names []string) ([]common.Action, error) {
+	out := make([]common.Action, len(names))
+	for i, a := range names {
+		action := common.Action_value[strings.ToUpper(fmt.Sprintf("action_%s", a))]
+		if action == int32(common.Action_ACTION_NONE) {
+			return nil, fmt.Errorf("cannot find action: %s", a)
+		}
+		out[i] = common.Action(action)
+	}
+	return out, nil
+}
+
+// diffRoleActions returns the actions of planned missing from prior, and the
+// actions of prior missing from planned.
+func diffRoleActions(prior, planned []common.Action) (added, removed []common.Action) {
+	have := make(map[common.Action]bool, len(prior))
+	for _, a := range prior {
+		have[a] = true
+	}
+	want := make(map[common.Action]bool, len(planned))
+	for _, a := range planned {
+		want[a] = true
+		if !have[a] {
+			added = append(added, a)
+		}
+	}
+	for _, a := range prior {
+		if !want[a] {
+			removed = append(removed, a)
+		}
+	}
+	return added, removed
+}
+
+// update applies the difference between the prior and planned role in place.
+// The description is written along with the prior actions, then actions are
+// added before any are removed, so bindings never lose a kept permission.
+func (r *RoleResource) update(ctx context.Context, prior, plan *RoleResourceModel) error {
+	var priorNames, plannedNames []string
+	if diags := prior.Actions.ElementsAs(ctx, &priorNames, false); diags.HasError() {
+		return fmt.Errorf("unable to read prior actions")
+	}
+	if diags := plan.Actions.ElementsAs(ctx, &plannedNames, false); diags.HasError() {
+		return fmt.Errorf("unable to read planned actions")
+	}
+	priorActions, err := roleActions(priorNames)
+	if err != nil {
+		return err
+	}
+	plannedActions, err := roleActions(plannedNames)
+	if err != nil {
+		return err
+	}
+
+	id := &common.RoleIdentifier{
+		Name:         plan.Id.ValueString(),
+		Organization: r.org,
+	}
+	if plan.Description.ValueString() != prior.Description.ValueString() {
+		updateRequest := &identity.UpdateRoleRequest{
+			Role: &common.Role{
+				Id: id,
+				RoleSpec: &common.RoleSpec{
+					Description: plan.Description.ValueString(),
+				},
+				RoleType: common.RoleType_ROLE_TYPE_CUSTOM,
+				Actions:  priorActions,
+			},
+		}
+		tflog.Debug(ctx, "UpdateRole request", map[string]interface{}{
+			"role(update)": updateRequest.Role,
+		})
+		if _, err := r.roles.UpdateRole(ctx, updateRequest); err != nil {
+			return err
+		}
+	}
+
+	added, removed := diffRoleActions(priorActions, plannedActions)
+	for _, a := range added {
+		if _, err := r.roles.CreateRoleAction(ctx, &identity.CreateRoleActionRequest{Id: id, Action: a}); err != nil {
+			return fmt.Errorf("unable to add action %s: %w", a, err)
+		}
+	}
+	for _, a := range removed {
+		if _, err := r.roles.DeleteRoleAction(ctx, &identity.DeleteRoleActionRequest{Id: id, Action: a}); err != nil {
+			return fmt.Errorf("unable to remove action %s: %w", a, err)
+		}
+	}
+	return nil
+}
+
 func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var data RoleResourceModel
 
@@ -136,7 +234,17 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 		return
 	}
 
-	actionErrors := false
+	var names []string
+	resp.Diagnostics.Append(data.Actions.ElementsAs(ctx, &names, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	actions, err := roleActions(names)
+	if err != nil {
+		resp.Diagnostics.AddError("Action does not exist", err.Error())
+		return
+	}
+
 	createRequest := &authorizer.CreateRoleRequest{
 		Role: &common.Role{
 			Id: &common.RoleIdentifier{
@@ -147,33 +255,14 @@ func (r *RoleResource) Create(ctx context.Context, req resource.CreateRequest, r
 				Description: data.Description.ValueString(),
 			},
 			RoleType: common.RoleType_ROLE_TYPE_CUSTOM,
-			Actions: func() []common.Action {
-				var actions []string
-				if diag := data.Actions.ElementsAs(ctx, &actions, false); diag.HasError() {
-					return nil
-				}
-				out := make([]common.Action, len(actions))
-				for i, a := range actions {
-					action := common.Action_value[strings.ToUpper(fmt.Sprintf("action_%s", a))]
-					if action == int32(common.Action_ACTION_NONE) {
-						resp.Diagnostics.AddError("Action does not exist", fmt.Sprintf("Cannot find action: %s", a))
-						actionErrors = true
-					}
-					out[i] = common.Action(action)
-				}
-				return out
-			}(),
+			Actions:  actions,
 		},
 	}
 
-	if actionErrors {
-		return
-	}
-
 	tflog.Debug(ctx, "CreateRole request", map[string]interface{}{
 		"role(create)": createRequest.Role,
 	})
-	_, err := r.conn.CreateRole(ctx, createRequest)
+	_, err = r.conn.CreateRole(ctx, createRequest)
 	if err != nil {
 		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create role, got error: %s", err))
 		return
@@ -224,15 +313,22 @@ func (r *RoleResource) Read(ctx context.Context, req resource.ReadRequest, resp
 }
 
 func (r *RoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
-	var data RoleResourceModel
+	var data, state RoleResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	data.Id = state.Id
+	if err := r.update(ctx, &state, &data); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update role %s, got error: %s", data.Id.ValueString(), err))
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
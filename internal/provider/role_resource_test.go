@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"github.com/unionai/cloud/gen/pb-go/identity"
+	"google.golang.org/grpc"
+)
+
+// mockRoleClient implements the subset of identity.RoleServiceClient used to
+// update roles, recording the calls in order.
+type mockRoleClient struct {
+	identity.RoleServiceClient
+	calls []string
+}
+
+func (m *mockRoleClient) UpdateRole(ctx context.Context, in *identity.UpdateRoleRequest, opts ...grpc.CallOption) (*identity.UpdateRoleResponse, error) {
+	m.calls = append(m.calls, "update:"+in.GetRole().GetRoleSpec().GetDescription())
+	return &identity.UpdateRoleResponse{}, nil
+}
+
+func (m *mockRoleClient) CreateRoleAction(ctx context.Context, in *identity.CreateRoleActionRequest, opts ...grpc.CallOption) (*identity.CreateRoleActionResponse, error) {
+	m.calls = append(m.calls, "add:"+in.GetAction().String())
+	return &identity.CreateRoleActionResponse{}, nil
+}
+
+func (m *mockRoleClient) DeleteRoleAction(ctx context.Context, in *identity.DeleteRoleActionRequest, opts ...grpc.CallOption) (*identity.DeleteRoleActionResponse, error) {
+	m.calls = append(m.calls, "remove:"+in.GetAction().String())
+	return &identity.DeleteRoleActionResponse{}, nil
+}
+
+func roleModel(description string, actions ...string) *RoleResourceModel {
+	values := make([]attr.Value, len(actions))
+	for i, a := range actions {
+		values[i] = types.StringValue(a)
+	}
+	return &RoleResourceModel{
+		Id:          types.StringValue("data-scientist"),
+		Name:        types.StringValue("data-scientist"),
+		Description: types.StringValue(description),
+		Actions:     types.SetValueMust(types.StringType, values),
+	}
+}
+
+func TestRoleResource_Update(t *testing.T) {
+	conn := &mockRoleClient{}
+	r := &RoleResource{roles: conn, org: "test-org"}
+
+	prior := roleModel("old", "view_flyte_inventory", "view_flyte_executions")
+	plan := roleModel("new", "view_flyte_inventory", "create_flyte_executions")
+	if err := r.update(context.Background(), prior, plan); err != nil {
+		t.Fatalf("update() returned error: %s", err)
+	}
+	expected := []string{"update:new", "add:ACTION_CREATE_FLYTE_EXECUTIONS", "remove:ACTION_VIEW_FLYTE_EXECUTIONS"}
+	if len(conn.calls) != len(expected) {
+		t.Fatalf("Expected calls %v, got %v", expected, conn.calls)
+	}
+	for i := range expected {
+		if conn.calls[i] != expected[i] {
+			t.Errorf("Expected calls %v, got %v", expected, conn.calls)
+		}
+	}
+
+	conn.calls = nil
+	if err := r.update(context.Background(), plan, plan); err != nil || len(conn.calls) != 0 {
+		t.Errorf("Expected no calls for an unchanged role, got %v (%v)", conn.calls, err)
+	}
+
+	if err := r.update(context.Background(), prior, roleModel("old", "fly_to_the_moon")); err == nil {
+		t.Error("Expected an unknown action to be rejected")
+	}
+}
+
+func TestDiffRoleActions(t *testing.T) {
+	added, removed := diffRoleActions(
+		[]common.Action{common.Action_ACTION_VIEW_FLYTE_INVENTORY},
+		[]common.Action{common.Action_ACTION_VIEW_FLYTE_INVENTORY, common.Action_ACTION_MANAGE_PERMISSIONS},
+	)
+	if len(added) != 1 || added[0] != common.Action_ACTION_MANAGE_PERMISSIONS || len(removed) != 0 {
+		t.Errorf("Unexpected diff: added %v, removed %v", added, removed)
+	}
+}
@@ -0,0 +1,421 @@
+package provider
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/secret"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &SecretResource{}
+var _ resource.ResourceWithImportState = &SecretResource{}
+var _ resource.ResourceWithValidateConfig = &SecretResource{}
+
+func NewSecretResource() resource.Resource {
+	return &SecretResource{}
+}
+
+// SecretResource manages a secret through the flyteidl2 SecretService. A
+// secret is scoped to the organization, and optionally narrowed to a project
+// and/or domain. The secret material is write-only: it is read from the
+// configuration on apply and never persisted in plan or state, so a change of
+// value is signalled through value_version.
+type SecretResource struct {
+	conn secret.SecretServiceClient
+	org  string
+}
+
+// SecretResourceModel describes the resource data model.
+type SecretResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	Name          types.String `tfsdk:"name"`
+	Project       types.String `tfsdk:"project"`
+	Domain        types.String `tfsdk:"domain"`
+	Type          types.String `tfsdk:"type"`
+	Value         types.String `tfsdk:"value"`
+	BinaryValue   types.String `tfsdk:"binary_value"`
+	ValueVersion  types.Int64  `tfsdk:"value_version"`
+	CreatedTime   types.String `tfsdk:"created_time"`
+	OverallStatus types.String `tfsdk:"overall_status"`
+}
+
+func (r *SecretResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_secret"
+}
+
+func (r *SecretResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Secret resource. Secrets are scoped to the organization, and optionally to a project and/or domain.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Secret identifier, in the form `{org}/{project}/{domain}/{name}`. Unset scopes are left empty.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Secret name",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Project the secret is scoped to. Leave unset for an organization-wide secret.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Domain the secret is scoped to. Leave unset for a secret shared across domains.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Secret type, either `SECRET_TYPE_GENERIC` or `SECRET_TYPE_IMAGE_PULL_SECRET`. Defaults to `SECRET_TYPE_GENERIC`.",
+				Default:             stringdefault.StaticString(secret.SecretType_SECRET_TYPE_GENERIC.String()),
+				Validators: []validator.String{
+					stringOneOf(
+						secret.SecretType_SECRET_TYPE_GENERIC.String(),
+						secret.SecretType_SECRET_TYPE_IMAGE_PULL_SECRET.String(),
+					),
+				},
+			},
+			"value": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				MarkdownDescription: "Secret value. For image pull secrets this is the docker config JSON. " +
+					"This value is write-only and is never stored in the plan or state; change `value_version` to push a new value. " +
+					"Exactly one of `value` or `binary_value` must be set.",
+			},
+			"binary_value": schema.StringAttribute{
+				Optional:  true,
+				Sensitive: true,
+				WriteOnly: true,
+				MarkdownDescription: "Base64-encoded binary secret value. " +
+					"This value is write-only and is never stored in the plan or state; change `value_version` to push a new value. " +
+					"Exactly one of `value` or `binary_value` must be set.",
+			},
+			"value_version": schema.Int64Attribute{
+				Optional:            true,
+				MarkdownDescription: "Version of the secret value. Since the value is write-only, changing it is not detected by Terraform; increment this to update the secret with the configured value.",
+			},
+			"created_time": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the secret was created, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"overall_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Presence of the secret across the organization's clusters (e.g. `FULLY_PRESENT`, `PARTIALLY_PRESENT`).",
+			},
+		},
+	}
+}
+
+func (r *SecretResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = secret.NewSecretServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *secret.SecretServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func (r *SecretResource) identifier(data *SecretResourceModel) *secret.SecretIdentifier {
+	return &secret.SecretIdentifier{
+		Name:         data.Name.ValueString(),
+		Organization: r.org,
+		Project:      data.Project.ValueString(),
+		Domain:       data.Domain.ValueString(),
+	}
+}
+
+func (r *SecretResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data SecretResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !data.Value.IsNull() && !data.BinaryValue.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("binary_value"),
+			"Conflicting Secret Values",
+			"Only one of value or binary_value can be set.",
+		)
+		return
+	}
+	if data.Value.IsNull() && data.BinaryValue.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("value"),
+			"Missing Secret Value",
+			"One of value or binary_value must be set.",
+		)
+	}
+}
+
+// spec builds the SecretSpec sent on create and update. The type comes from
+// the plan, while the write-only value fields are only present in the config.
+func (r *SecretResource) spec(plan *SecretResourceModel, config *SecretResourceModel) (*secret.SecretSpec, error) {
+	secretType, ok := secret.SecretType_value[plan.Type.ValueString()]
+	if !ok {
+		return nil, fmt.Errorf("invalid secret type %q, must be one of SECRET_TYPE_GENERIC or SECRET_TYPE_IMAGE_PULL_SECRET", plan.Type.ValueString())
+	}
+
+	spec := &secret.SecretSpec{
+		Type: secret.SecretType(secretType),
+	}
+	if !config.BinaryValue.IsNull() {
+		value, err := base64.StdEncoding.DecodeString(config.BinaryValue.ValueString())
+		if err != nil {
+			return nil, fmt.Errorf("binary_value is not valid base64: %w", err)
+		}
+		spec.Value = &secret.SecretSpec_BinaryValue{
+			BinaryValue: value,
+		}
+	} else {
+		spec.Value = &secret.SecretSpec_StringValue{
+			StringValue: config.Value.ValueString(),
+		}
+	}
+	return spec, nil
+}
+
+// refresh copies the server-side metadata of a secret into the model.
+func (r *SecretResource) refresh(data *SecretResourceModel, s *secret.Secret) {
+	md := s.GetSecretMetadata()
+	data.Type = types.StringValue(md.GetType().String())
+	data.CreatedTime = convertTimestampToString(md.GetCreatedTime())
+	data.OverallStatus = types.StringValue(md.GetSecretStatus().GetOverallStatus().String())
+}
+
+func (r *SecretResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data SecretResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only values are only available in the configuration
+	var config SecretResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := r.spec(&data, &config)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Secret", err.Error())
+		return
+	}
+
+	id := r.identifier(&data)
+	if _, err := r.conn.CreateSecret(ctx, &secret.CreateSecretRequest{
+		Id:         id,
+		SecretSpec: spec,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create secret %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	data.Id = types.StringValue(secretId(id))
+
+	got, err := r.conn.GetSecret(ctx, &secret.GetSecretRequest{Id: id})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret %s after create, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refresh(&data, got.GetSecret())
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data SecretResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := r.identifier(&data)
+	got, err := r.conn.GetSecret(ctx, &secret.GetSecretRequest{Id: id})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// The secret value is never returned by the API, so it is kept from state.
+	data.Id = types.StringValue(secretId(id))
+	r.refresh(&data, got.GetSecret())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data SecretResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only values are only available in the configuration
+	var config SecretResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := r.spec(&data, &config)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Secret", err.Error())
+		return
+	}
+
+	id := r.identifier(&data)
+	if _, err := r.conn.UpdateSecret(ctx, &secret.UpdateSecretRequest{
+		Id:         id,
+		SecretSpec: spec,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update secret %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	data.Id = types.StringValue(secretId(id))
+
+	got, err := r.conn.GetSecret(ctx, &secret.GetSecretRequest{Id: id})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read secret %s after update, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refresh(&data, got.GetSecret())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *SecretResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data SecretResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.conn.DeleteSecret(ctx, &secret.DeleteSecretRequest{
+		Id: r.identifier(&data),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete secret %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+// ImportState accepts an identifier in the form "{org}/{project}/{domain}/{name}".
+// Project and domain may be empty for secrets with a wider scope, e.g. "my-org///name".
+func (r *SecretResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := parseSecretId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError("Unexpected Import Identifier", err.Error())
+		return
+	}
+	if id.Organization != r.org {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Secret organization %q does not match the provider organization %q", id.Organization, r.org),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.Name)...)
+	if id.Project != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), id.Project)...)
+	}
+	if id.Domain != "" {
+		resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), id.Domain)...)
+	}
+}
+
+func secretId(id *secret.SecretIdentifier) string {
+	return strings.Join([]string{id.GetOrganization(), id.GetProject(), id.GetDomain(), id.GetName()}, "/")
+}
+
+func parseSecretId(id string) (*secret.SecretIdentifier, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 4 || parts[0] == "" || parts[3] == "" {
+		return nil, fmt.Errorf("expected import identifier in the form \"org/project/domain/name\", got: %q", id)
+	}
+	return &secret.SecretIdentifier{
+		Organization: parts[0],
+		Project:      parts[1],
+		Domain:       parts[2],
+		Name:         parts[3],
+	}, nil
+}
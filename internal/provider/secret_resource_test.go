@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/secret"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestSecretResource_Metadata(t *testing.T) {
+	r := NewSecretResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "unionai"}, resp)
+
+	if resp.TypeName != "unionai_secret" {
+		t.Errorf("Expected type name 'unionai_secret', got '%s'", resp.TypeName)
+	}
+}
+
+func TestSecretResource_Schema(t *testing.T) {
+	r := NewSecretResource()
+	resp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, resp)
+
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Schema() returned errors: %v", resp.Diagnostics.Errors())
+	}
+	for _, attr := range []string{"id", "name", "project", "domain", "type", "value", "binary_value", "value_version", "created_time", "overall_status"} {
+		if _, ok := resp.Schema.Attributes[attr]; !ok {
+			t.Errorf("Expected '%s' attribute in schema", attr)
+		}
+	}
+}
+
+func TestSecretId_RoundTrip(t *testing.T) {
+	for _, id := range []string{
+		"my-org/my-project/development/db-password",
+		"my-org/my-project//db-password",
+		"my-org///db-password",
+	} {
+		parsed, err := parseSecretId(id)
+		if err != nil {
+			t.Fatalf("parseSecretId(%q) returned error: %s", id, err)
+		}
+		if got := secretId(parsed); got != id {
+			t.Errorf("Expected round trip of %q, got %q", id, got)
+		}
+	}
+}
+
+func TestParseSecretId_Invalid(t *testing.T) {
+	for _, id := range []string{
+		"",
+		"db-password",
+		"my-org/db-password",
+		"/my-project/development/db-password",
+		"my-org/my-project/development/",
+		"my-org/my-project/development/db/password",
+	} {
+		if _, err := parseSecretId(id); err == nil {
+			t.Errorf("Expected parseSecretId(%q) to fail", id)
+		}
+	}
+}
+
+func TestSecretResource_Spec(t *testing.T) {
+	r := &SecretResource{org: "test-org"}
+	plan := &SecretResourceModel{Type: types.StringValue("SECRET_TYPE_IMAGE_PULL_SECRET")}
+
+	spec, err := r.spec(plan, &SecretResourceModel{
+		Value:       types.StringValue("{}"),
+		BinaryValue: types.StringNull(),
+	})
+	if err != nil {
+		t.Fatalf("spec() returned error: %s", err)
+	}
+	if spec.GetType() != secret.SecretType_SECRET_TYPE_IMAGE_PULL_SECRET {
+		t.Errorf("Expected image pull secret type, got %s", spec.GetType())
+	}
+	if spec.GetStringValue() != "{}" {
+		t.Errorf("Expected string value '{}', got '%s'", spec.GetStringValue())
+	}
+
+	if _, err := r.spec(&SecretResourceModel{Type: types.StringValue("SECRET_TYPE_BOGUS")}, &SecretResourceModel{
+		Value:       types.StringValue("x"),
+		BinaryValue: types.StringNull(),
+	}); err == nil {
+		t.Error("Expected spec() to reject an unknown secret type")
+	}
+
+	// Types are read back in their canonical form, so other spellings are rejected.
+	if _, err := r.spec(&SecretResourceModel{Type: types.StringValue("secret_type_generic")}, &SecretResourceModel{
+		Value:       types.StringValue("x"),
+		BinaryValue: types.StringNull(),
+	}); err == nil {
+		t.Error("Expected spec() to reject a lower-case secret type")
+	}
+}
+
+func TestSecretResource_Spec_BinaryValue(t *testing.T) {
+	r := &SecretResource{org: "test-org"}
+	plan := &SecretResourceModel{Type: types.StringValue("SECRET_TYPE_GENERIC")}
+
+	spec, err := r.spec(plan, &SecretResourceModel{
+		Value:       types.StringNull(),
+		BinaryValue: types.StringValue("AAEC/w=="),
+	})
+	if err != nil {
+		t.Fatalf("spec() returned error: %s", err)
+	}
+	if got := spec.GetBinaryValue(); string(got) != "\x00\x01\x02\xff" {
+		t.Errorf("Expected decoded binary value, got %v", got)
+	}
+
+	if _, err := r.spec(plan, &SecretResourceModel{
+		Value:       types.StringNull(),
+		BinaryValue: types.StringValue("not base64!"),
+	}); err == nil {
+		t.Error("Expected spec() to reject an invalid base64 binary value")
+	}
+}
@@ -0,0 +1,1214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	flyteapp "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/app"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/core"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &ServingAppResource{}
+var _ resource.ResourceWithImportState = &ServingAppResource{}
+var _ resource.ResourceWithValidateConfig = &ServingAppResource{}
+
+func NewServingAppResource() resource.Resource {
+	return &ServingAppResource{}
+}
+
+// ServingAppResource manages a long-running app, such as a model server or a
+// dashboard, through the flyteidl2 AppService. Updates are optimistically
+// locked on the revision of the app. Unless disabled, create and update wait
+// for the new revision to become ready.
+type ServingAppResource struct {
+	conn flyteapp.AppServiceClient
+	logs flyteapp.AppLogsServiceClient
+	org  string
+}
+
+// ServingAppResourceModel describes the resource data model.
+type ServingAppResourceModel struct {
+	Id               types.String                    `tfsdk:"id"`
+	Name             types.String                    `tfsdk:"name"`
+	Project          types.String                    `tfsdk:"project"`
+	Domain           types.String                    `tfsdk:"domain"`
+	Labels           types.Map                       `tfsdk:"labels"`
+	DesiredState     types.String                    `tfsdk:"desired_state"`
+	ClusterPool      types.String                    `tfsdk:"cluster_pool"`
+	Container        *ServingAppContainerModel       `tfsdk:"container"`
+	Pod              *ServingAppPodModel             `tfsdk:"pod"`
+	Autoscaling      *ServingAppAutoscalingModel     `tfsdk:"autoscaling"`
+	Ingress          *ServingAppIngressModel         `tfsdk:"ingress"`
+	SecurityContext  *ServingAppSecurityContextModel `tfsdk:"security_context"`
+	Inputs           []ServingAppInputModel          `tfsdk:"input"`
+	WaitForReady     types.Bool                      `tfsdk:"wait_for_ready"`
+	FailureLogLines  types.Int64                     `tfsdk:"failure_log_lines"`
+	Timeouts         *ServingAppTimeoutsModel        `tfsdk:"timeouts"`
+	Revision         types.Int64                     `tfsdk:"revision"`
+	DeploymentStatus types.String                    `tfsdk:"deployment_status"`
+	PublicUrl        types.String                    `tfsdk:"public_url"`
+	CnameUrl         types.String                    `tfsdk:"cname_url"`
+	VpcUrl           types.String                    `tfsdk:"vpc_url"`
+	CreatedAt        types.String                    `tfsdk:"created_at"`
+}
+
+type ServingAppContainerModel struct {
+	Image    types.String `tfsdk:"image"`
+	Command  types.List   `tfsdk:"command"`
+	Args     types.List   `tfsdk:"args"`
+	Env      types.Map    `tfsdk:"env"`
+	Ports    types.List   `tfsdk:"ports"`
+	Requests types.Map    `tfsdk:"requests"`
+	Limits   types.Map    `tfsdk:"limits"`
+}
+
+type ServingAppPodModel struct {
+	PodSpec              types.String `tfsdk:"pod_spec"`
+	PrimaryContainerName types.String `tfsdk:"primary_container_name"`
+	Labels               types.Map    `tfsdk:"labels"`
+	Annotations          types.Map    `tfsdk:"annotations"`
+}
+
+type ServingAppAutoscalingModel struct {
+	MinReplicas     types.Int64  `tfsdk:"min_replicas"`
+	MaxReplicas     types.Int64  `tfsdk:"max_replicas"`
+	ScaledownPeriod types.String `tfsdk:"scaledown_period"`
+	Concurrency     types.Int64  `tfsdk:"concurrency"`
+	RequestRate     types.Int64  `tfsdk:"request_rate"`
+}
+
+type ServingAppIngressModel struct {
+	Private   types.Bool   `tfsdk:"private"`
+	Subdomain types.String `tfsdk:"subdomain"`
+	Cname     types.String `tfsdk:"cname"`
+}
+
+type ServingAppSecurityContextModel struct {
+	RunAs          *ServingAppRunAsModel   `tfsdk:"run_as"`
+	Secrets        []ServingAppSecretModel `tfsdk:"secret"`
+	AllowAnonymous types.Bool              `tfsdk:"allow_anonymous"`
+}
+
+type ServingAppRunAsModel struct {
+	IamRole           types.String `tfsdk:"iam_role"`
+	K8sServiceAccount types.String `tfsdk:"k8s_service_account"`
+}
+
+type ServingAppSecretModel struct {
+	Group            types.String `tfsdk:"group"`
+	Key              types.String `tfsdk:"key"`
+	GroupVersion     types.String `tfsdk:"group_version"`
+	MountRequirement types.String `tfsdk:"mount_requirement"`
+	EnvVar           types.String `tfsdk:"env_var"`
+}
+
+type ServingAppTimeoutsModel struct {
+	Create types.String `tfsdk:"create"`
+	Update types.String `tfsdk:"update"`
+}
+
+type ServingAppInputModel struct {
+	Name  types.String `tfsdk:"name"`
+	Value types.String `tfsdk:"value"`
+	AppId types.String `tfsdk:"app_id"`
+}
+
+func (r *ServingAppResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_serving_app"
+}
+
+func (r *ServingAppResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Serving app resource. A serving app is a long-running container or pod, such as a model server or a dashboard, exposed through an ingress.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "App identifier, in the form `{project}/{domain}/{name}`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "App name, unique per project and domain.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Project the app is deployed to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain the app is deployed to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Labels attached to the app.",
+			},
+			"desired_state": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Desired state of the app, either `active` or `stopped`. Defaults to `active`.",
+				Default:             stringdefault.StaticString("active"),
+				Validators:          []validator.String{stringOneOf("active", "stopped")},
+			},
+			"cluster_pool": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cluster pool the app is scheduled on. If unset, the default cluster pool is used.",
+			},
+			"wait_for_ready": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether create and update wait for the new revision to become ready, failing with the condition message if the rollout fails. Defaults to `true`.",
+				Default:             booldefault.StaticBool(true),
+			},
+			"failure_log_lines": schema.Int64Attribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Number of recent log lines included in the error when a rollout fails or times out. `0` disables logs. Defaults to `50`.",
+				Default:             int64default.StaticInt64(defaultServingAppLogLines),
+			},
+			"revision": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Latest revision of the app.",
+			},
+			"deployment_status": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Latest deployment status of the app (e.g. `active`, `pending`, `failed`).",
+			},
+			"public_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Public URL of the app.",
+			},
+			"cname_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL of the app under its CNAME, if one is configured.",
+			},
+			"vpc_url": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "URL of the app within the VPC.",
+			},
+			"created_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the app was created, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"container": schema.SingleNestedBlock{
+				MarkdownDescription: "Container payload. Conflicts with `pod`.",
+				Attributes: map[string]schema.Attribute{
+					"image": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Container image (e.g. `ghcr.io/org/server:1.0`).",
+					},
+					"command": schema.ListAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Entrypoint of the container. If unset, the image entrypoint is used.",
+					},
+					"args": schema.ListAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Arguments passed to the entrypoint.",
+					},
+					"env": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Environment variables set in the container.",
+					},
+					"ports": schema.ListAttribute{
+						ElementType:         types.Int64Type,
+						Optional:            true,
+						MarkdownDescription: "Ports the container listens on. The first port receives the ingress traffic.",
+					},
+					"requests": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Requested resources, keyed by `cpu`, `gpu`, `memory`, `storage` or `ephemeral_storage`.",
+						Validators:          []validator.Map{servingAppResourceNameValidator},
+					},
+					"limits": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Resource limits, keyed by `cpu`, `gpu`, `memory`, `storage` or `ephemeral_storage`.",
+						Validators:          []validator.Map{servingAppResourceNameValidator},
+					},
+				},
+			},
+			"pod": schema.SingleNestedBlock{
+				MarkdownDescription: "Kubernetes pod payload. Conflicts with `container`.",
+				Attributes: map[string]schema.Attribute{
+					"pod_spec": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Kubernetes pod spec, as a JSON document (e.g. from `jsonencode`).",
+					},
+					"primary_container_name": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Name of the container in the pod spec that serves the app.",
+					},
+					"labels": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Labels added to the pod.",
+					},
+					"annotations": schema.MapAttribute{
+						ElementType:         types.StringType,
+						Optional:            true,
+						MarkdownDescription: "Annotations added to the pod.",
+					},
+				},
+			},
+			"autoscaling": schema.SingleNestedBlock{
+				MarkdownDescription: "Autoscaling configuration.",
+				Attributes: map[string]schema.Attribute{
+					"min_replicas": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Minimum number of replicas. `0` lets the app scale to zero.",
+					},
+					"max_replicas": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Maximum number of replicas.",
+					},
+					"scaledown_period": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Time without traffic before a replica is removed, as a Go duration (e.g. `10m`).",
+					},
+					"concurrency": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Target number of in-flight requests per replica. Conflicts with `request_rate`.",
+					},
+					"request_rate": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Target number of requests per second per replica. Conflicts with `concurrency`.",
+					},
+				},
+			},
+			"ingress": schema.SingleNestedBlock{
+				MarkdownDescription: "Ingress configuration.",
+				Attributes: map[string]schema.Attribute{
+					"private": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Whether the app is only reachable from within the VPC.",
+					},
+					"subdomain": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Subdomain the app is served under. If unset, one is generated.",
+					},
+					"cname": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Custom domain name the app is also served under.",
+					},
+				},
+			},
+			"security_context": schema.SingleNestedBlock{
+				MarkdownDescription: "Identity and secrets of the app.",
+				Attributes: map[string]schema.Attribute{
+					"allow_anonymous": schema.BoolAttribute{
+						Optional:            true,
+						MarkdownDescription: "Whether unauthenticated requests reach the app.",
+					},
+				},
+				Blocks: map[string]schema.Block{
+					"run_as": schema.SingleNestedBlock{
+						MarkdownDescription: "Identity the app runs as.",
+						Attributes: map[string]schema.Attribute{
+							"iam_role": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "IAM role to assume.",
+							},
+							"k8s_service_account": schema.StringAttribute{
+								Optional:            true,
+								MarkdownDescription: "Kubernetes service account to run as.",
+							},
+						},
+					},
+					"secret": schema.ListNestedBlock{
+						MarkdownDescription: "Secret mounted into the app.",
+						NestedObject: schema.NestedBlockObject{
+							Attributes: map[string]schema.Attribute{
+								"group": schema.StringAttribute{
+									Required:            true,
+									MarkdownDescription: "Secret group, or the secret name for single-value secrets.",
+								},
+								"key": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "Key within the secret group.",
+								},
+								"group_version": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "Version of the secret group.",
+								},
+								"mount_requirement": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "How the secret is mounted, one of `any`, `env_var` or `file`.",
+									Validators:          []validator.String{stringOneOf("any", "env_var", "file")},
+								},
+								"env_var": schema.StringAttribute{
+									Optional:            true,
+									MarkdownDescription: "Environment variable that receives the secret, or its path when mounted as a file.",
+								},
+							},
+						},
+					},
+				},
+			},
+			"timeouts": schema.SingleNestedBlock{
+				MarkdownDescription: "How long to wait for the app to become ready.",
+				Attributes: map[string]schema.Attribute{
+					"create": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Timeout for create, as a Go duration (e.g. `30m`). Defaults to `20m`.",
+					},
+					"update": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Timeout for update, as a Go duration (e.g. `30m`). Defaults to `20m`.",
+					},
+				},
+			},
+			"input": schema.ListNestedBlock{
+				MarkdownDescription: "Input passed to the app at runtime.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							Required:            true,
+							MarkdownDescription: "Input name.",
+						},
+						"value": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "String value of the input. Conflicts with `app_id`.",
+						},
+						"app_id": schema.StringAttribute{
+							Optional:            true,
+							MarkdownDescription: "Another app whose endpoint is passed as the input, in the form `{project}/{domain}/{name}`. Conflicts with `value`.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *ServingAppResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = flyteapp.NewAppServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *app.AppServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.logs = flyteapp.NewAppLogsServiceClient(client.conn)
+	r.org = client.org
+}
+
+func (r *ServingAppResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data ServingAppResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if (data.Container == nil) == (data.Pod == nil) {
+		resp.Diagnostics.AddError(
+			"Invalid App Payload",
+			"Exactly one of the container or pod blocks must be set.",
+		)
+	}
+	if data.Container != nil && data.Container.Image.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("container").AtName("image"),
+			"Missing Container Image",
+			"The container block requires an image.",
+		)
+	}
+	if data.Pod != nil {
+		if data.Pod.PodSpec.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pod").AtName("pod_spec"),
+				"Missing Pod Spec",
+				"The pod block requires a pod_spec.",
+			)
+		} else if !data.Pod.PodSpec.IsUnknown() && !json.Valid([]byte(data.Pod.PodSpec.ValueString())) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("pod").AtName("pod_spec"),
+				"Invalid Pod Spec",
+				"pod_spec must be a JSON document.",
+			)
+		}
+	}
+	if a := data.Autoscaling; a != nil {
+		if !a.Concurrency.IsNull() && !a.RequestRate.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("autoscaling"),
+				"Conflicting Scaling Metrics",
+				"Only one of concurrency or request_rate can be set.",
+			)
+		}
+		if !a.MinReplicas.IsNull() && !a.MaxReplicas.IsNull() && a.MinReplicas.ValueInt64() > a.MaxReplicas.ValueInt64() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("autoscaling").AtName("min_replicas"),
+				"Invalid Replicas",
+				"min_replicas must not be greater than max_replicas.",
+			)
+		}
+		if !a.ScaledownPeriod.IsNull() && !a.ScaledownPeriod.IsUnknown() {
+			if _, err := time.ParseDuration(a.ScaledownPeriod.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("autoscaling").AtName("scaledown_period"),
+					"Invalid Scaledown Period",
+					fmt.Sprintf("Unable to parse scaledown_period, got error: %s", err),
+				)
+			}
+		}
+	}
+	if data.Timeouts != nil {
+		for name, value := range map[string]types.String{"create": data.Timeouts.Create, "update": data.Timeouts.Update} {
+			if value.IsNull() || value.IsUnknown() {
+				continue
+			}
+			if _, err := time.ParseDuration(value.ValueString()); err != nil {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("timeouts").AtName(name),
+					"Invalid Timeout",
+					fmt.Sprintf("Unable to parse %s timeout, got error: %s", name, err),
+				)
+			}
+		}
+	}
+	if !data.FailureLogLines.IsNull() && !data.FailureLogLines.IsUnknown() && data.FailureLogLines.ValueInt64() < 0 {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("failure_log_lines"),
+			"Invalid Log Lines",
+			"failure_log_lines must not be negative.",
+		)
+	}
+	for i, input := range data.Inputs {
+		if input.Value.IsNull() == input.AppId.IsNull() {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("input").AtListIndex(i),
+				"Invalid App Input",
+				"Exactly one of value or app_id must be set.",
+			)
+		}
+	}
+}
+
+// timeout returns the configured create or update timeout.
+func (r *ServingAppResource) timeout(data *ServingAppResourceModel, operation string) time.Duration {
+	if data.Timeouts == nil {
+		return defaultServingAppTimeout
+	}
+	value := data.Timeouts.Create
+	if operation == "update" {
+		value = data.Timeouts.Update
+	}
+	timeout, err := time.ParseDuration(value.ValueString())
+	if err != nil {
+		return defaultServingAppTimeout
+	}
+	return timeout
+}
+
+func (r *ServingAppResource) appId(data *ServingAppResourceModel) *flyteapp.Identifier {
+	return &flyteapp.Identifier{
+		Org:     r.org,
+		Project: data.Project.ValueString(),
+		Domain:  data.Domain.ValueString(),
+		Name:    data.Name.ValueString(),
+	}
+}
+
+// spec builds the app spec from the model.
+func (r *ServingAppResource) spec(data *ServingAppResourceModel) (*flyteapp.Spec, error) {
+	desiredState, err := servingAppDesiredState(data.DesiredState.ValueString())
+	if err != nil {
+		return nil, err
+	}
+	spec := &flyteapp.Spec{
+		DesiredState: desiredState,
+		ClusterPool:  data.ClusterPool.ValueString(),
+	}
+
+	switch {
+	case data.Container != nil:
+		container, err := servingAppContainer(data.Container)
+		if err != nil {
+			return nil, err
+		}
+		spec.AppPayload = &flyteapp.Spec_Container{Container: container}
+	case data.Pod != nil:
+		podSpec := &structpb.Struct{}
+		if err := protojson.Unmarshal([]byte(data.Pod.PodSpec.ValueString()), podSpec); err != nil {
+			return nil, fmt.Errorf("invalid pod_spec: %w", err)
+		}
+		pod := &core.K8SPod{
+			PodSpec:              podSpec,
+			PrimaryContainerName: data.Pod.PrimaryContainerName.ValueString(),
+		}
+		if !data.Pod.Labels.IsNull() || !data.Pod.Annotations.IsNull() {
+			pod.Metadata = &core.K8SObjectMetadata{
+				Labels:      convertMapToStrings(data.Pod.Labels),
+				Annotations: convertMapToStrings(data.Pod.Annotations),
+			}
+		}
+		spec.AppPayload = &flyteapp.Spec_Pod{Pod: pod}
+	}
+
+	if a := data.Autoscaling; a != nil {
+		autoscaling := &flyteapp.AutoscalingConfig{}
+		if !a.MinReplicas.IsNull() || !a.MaxReplicas.IsNull() {
+			autoscaling.Replicas = &flyteapp.Replicas{
+				Min: uint32(a.MinReplicas.ValueInt64()),
+				Max: uint32(a.MaxReplicas.ValueInt64()),
+			}
+		}
+		if !a.ScaledownPeriod.IsNull() {
+			period, err := time.ParseDuration(a.ScaledownPeriod.ValueString())
+			if err != nil {
+				return nil, fmt.Errorf("invalid scaledown_period %q: %w", a.ScaledownPeriod.ValueString(), err)
+			}
+			autoscaling.ScaledownPeriod = durationpb.New(period)
+		}
+		switch {
+		case !a.Concurrency.IsNull():
+			autoscaling.ScalingMetric = &flyteapp.ScalingMetric{
+				Metric: &flyteapp.ScalingMetric_Concurrency{
+					Concurrency: &flyteapp.Concurrency{TargetValue: uint32(a.Concurrency.ValueInt64())},
+				},
+			}
+		case !a.RequestRate.IsNull():
+			autoscaling.ScalingMetric = &flyteapp.ScalingMetric{
+				Metric: &flyteapp.ScalingMetric_RequestRate{
+					RequestRate: &flyteapp.RequestRate{TargetValue: uint32(a.RequestRate.ValueInt64())},
+				},
+			}
+		}
+		spec.Autoscaling = autoscaling
+	}
+
+	if data.Ingress != nil {
+		spec.Ingress = &flyteapp.IngressConfig{
+			Private:   data.Ingress.Private.ValueBool(),
+			Subdomain: data.Ingress.Subdomain.ValueString(),
+			Cname:     data.Ingress.Cname.ValueString(),
+		}
+	}
+
+	if sc := data.SecurityContext; sc != nil {
+		securityContext := &flyteapp.SecurityContext{
+			AllowAnonymous: sc.AllowAnonymous.ValueBool(),
+		}
+		if sc.RunAs != nil {
+			securityContext.RunAs = &core.Identity{
+				IamRole:           sc.RunAs.IamRole.ValueString(),
+				K8SServiceAccount: sc.RunAs.K8sServiceAccount.ValueString(),
+			}
+		}
+		for _, s := range sc.Secrets {
+			mount := core.Secret_ANY
+			if !s.MountRequirement.IsNull() {
+				value, ok := servingAppMountTypes[s.MountRequirement.ValueString()]
+				if !ok {
+					return nil, fmt.Errorf("invalid secret mount_requirement %q, must be one of any, env_var or file", s.MountRequirement.ValueString())
+				}
+				mount = value
+			}
+			securityContext.Secrets = append(securityContext.Secrets, &core.Secret{
+				Group:            s.Group.ValueString(),
+				Key:              s.Key.ValueString(),
+				GroupVersion:     s.GroupVersion.ValueString(),
+				MountRequirement: mount,
+				EnvVar:           s.EnvVar.ValueString(),
+			})
+		}
+		spec.SecurityContext = securityContext
+	}
+
+	if len(data.Inputs) > 0 {
+		inputs := &flyteapp.InputList{}
+		for _, in := range data.Inputs {
+			input := &flyteapp.Input{Name: in.Name.ValueString()}
+			if !in.AppId.IsNull() {
+				id, err := r.parseAppId(in.AppId.ValueString())
+				if err != nil {
+					return nil, fmt.Errorf("invalid app_id for input %s: %w", in.Name.ValueString(), err)
+				}
+				input.Value = &flyteapp.Input_AppId{AppId: id}
+			} else {
+				input.Value = &flyteapp.Input_StringValue{StringValue: in.Value.ValueString()}
+			}
+			inputs.Items = append(inputs.Items, input)
+		}
+		spec.Inputs = inputs
+	}
+
+	return spec, nil
+}
+
+func servingAppContainer(data *ServingAppContainerModel) (*core.Container, error) {
+	container := &core.Container{
+		Image:   data.Image.ValueString(),
+		Command: convertListToStrings(data.Command),
+		Args:    convertListToStrings(data.Args),
+	}
+
+	env := convertMapToStrings(data.Env)
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		container.Env = append(container.Env, &core.KeyValuePair{Key: key, Value: env[key]})
+	}
+
+	for _, port := range data.Ports.Elements() {
+		container.Ports = append(container.Ports, &core.ContainerPort{
+			ContainerPort: uint32(port.(types.Int64).ValueInt64()),
+		})
+	}
+
+	if !data.Requests.IsNull() || !data.Limits.IsNull() {
+		requests, err := servingAppResourceEntries(data.Requests)
+		if err != nil {
+			return nil, err
+		}
+		limits, err := servingAppResourceEntries(data.Limits)
+		if err != nil {
+			return nil, err
+		}
+		container.Resources = &core.Resources{Requests: requests, Limits: limits}
+	}
+	return container, nil
+}
+
+// servingAppResourceNames maps the configured resource names to their API
+// values. They are the lower-cased enum names, as read back by
+// servingAppResourceMap.
+var servingAppResourceNames = map[string]core.Resources_ResourceName{
+	"cpu":               core.Resources_CPU,
+	"gpu":               core.Resources_GPU,
+	"memory":            core.Resources_MEMORY,
+	"storage":           core.Resources_STORAGE,
+	"ephemeral_storage": core.Resources_EPHEMERAL_STORAGE,
+}
+
+var servingAppResourceNameValidator = mapKeysOneOf("cpu", "gpu", "memory", "storage", "ephemeral_storage")
+
+// servingAppMountTypes maps the configured secret mount requirements to
+// their API values.
+var servingAppMountTypes = map[string]core.Secret_MountType{
+	"any":     core.Secret_ANY,
+	"env_var": core.Secret_ENV_VAR,
+	"file":    core.Secret_FILE,
+}
+
+// servingAppDesiredStates maps the configured desired states to their API
+// values.
+var servingAppDesiredStates = map[string]flyteapp.Spec_DesiredState{
+	"active":  flyteapp.Spec_DESIRED_STATE_ACTIVE,
+	"stopped": flyteapp.Spec_DESIRED_STATE_STOPPED,
+}
+
+func servingAppResourceEntries(input types.Map) ([]*core.Resources_ResourceEntry, error) {
+	values := convertMapToStrings(input)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	entries := make([]*core.Resources_ResourceEntry, 0, len(keys))
+	for _, key := range keys {
+		name, ok := servingAppResourceNames[key]
+		if !ok {
+			return nil, fmt.Errorf("invalid resource %q, must be one of cpu, gpu, memory, storage or ephemeral_storage", key)
+		}
+		entries = append(entries, &core.Resources_ResourceEntry{
+			Name:  name,
+			Value: values[key],
+		})
+	}
+	return entries, nil
+}
+
+// servingAppDesiredState maps the short desired state name to its enum value.
+func servingAppDesiredState(value string) (flyteapp.Spec_DesiredState, error) {
+	state, ok := servingAppDesiredStates[value]
+	if !ok {
+		return 0, fmt.Errorf("invalid desired state %q, must be one of active or stopped", value)
+	}
+	return state, nil
+}
+
+// servingAppDeploymentStatus returns the latest deployment status of an app.
+// Conditions are ordered oldest first.
+func servingAppDeploymentStatus(s *flyteapp.Status) types.String {
+	conditions := s.GetConditions()
+	if len(conditions) == 0 {
+		return types.StringNull()
+	}
+	name := conditions[len(conditions)-1].GetDeploymentStatus().String()
+	return types.StringValue(strings.ToLower(strings.TrimPrefix(name, "DEPLOYMENT_STATUS_")))
+}
+
+// refresh copies the remote app into the model. Values that are equivalent
+// to the configured ones, such as a reformatted pod spec or duration, keep
+// their configured form.
+func (r *ServingAppResource) refresh(data *ServingAppResourceModel, a *flyteapp.App) {
+	id := a.GetMetadata().GetId()
+	data.Id = types.StringValue(servingAppId(id))
+	data.Name = types.StringValue(id.GetName())
+	data.Project = types.StringValue(id.GetProject())
+	data.Domain = types.StringValue(id.GetDomain())
+	data.Labels = convertStringsToMap(a.GetMetadata().GetLabels())
+	r.refreshComputed(data, a)
+
+	spec := a.GetSpec()
+	switch spec.GetDesiredState() {
+	case flyteapp.Spec_DESIRED_STATE_ACTIVE, flyteapp.Spec_DESIRED_STATE_STARTED:
+		// STARTED is the deprecated name of ACTIVE
+		data.DesiredState = types.StringValue("active")
+	case flyteapp.Spec_DESIRED_STATE_STOPPED:
+		data.DesiredState = types.StringValue("stopped")
+	}
+	data.ClusterPool = optionalString(spec.GetClusterPool())
+
+	data.Container = nil
+	if container := spec.GetContainer(); container != nil {
+		env := make(map[string]string, len(container.GetEnv()))
+		for _, kv := range container.GetEnv() {
+			env[kv.GetKey()] = kv.GetValue()
+		}
+		ports := types.ListNull(types.Int64Type)
+		if len(container.GetPorts()) > 0 {
+			values := make([]attr.Value, 0, len(container.GetPorts()))
+			for _, port := range container.GetPorts() {
+				values = append(values, types.Int64Value(int64(port.GetContainerPort())))
+			}
+			ports = types.ListValueMust(types.Int64Type, values)
+		}
+		data.Container = &ServingAppContainerModel{
+			Image:    types.StringValue(container.GetImage()),
+			Command:  convertStringsToList(container.GetCommand()),
+			Args:     convertStringsToList(container.GetArgs()),
+			Env:      convertStringsToMap(env),
+			Ports:    ports,
+			Requests: servingAppResourceMap(container.GetResources().GetRequests()),
+			Limits:   servingAppResourceMap(container.GetResources().GetLimits()),
+		}
+	}
+
+	var priorPodSpec types.String
+	if data.Pod != nil {
+		priorPodSpec = data.Pod.PodSpec
+	}
+	data.Pod = nil
+	if pod := spec.GetPod(); pod != nil {
+		data.Pod = &ServingAppPodModel{
+			PodSpec:              servingAppPodSpec(priorPodSpec, pod.GetPodSpec()),
+			PrimaryContainerName: optionalString(pod.GetPrimaryContainerName()),
+			Labels:               convertStringsToMap(pod.GetMetadata().GetLabels()),
+			Annotations:          convertStringsToMap(pod.GetMetadata().GetAnnotations()),
+		}
+	}
+
+	var priorPeriod types.String
+	if data.Autoscaling != nil {
+		priorPeriod = data.Autoscaling.ScaledownPeriod
+	}
+	data.Autoscaling = nil
+	if autoscaling := spec.GetAutoscaling(); autoscaling != nil {
+		model := &ServingAppAutoscalingModel{
+			MinReplicas:     types.Int64Null(),
+			MaxReplicas:     types.Int64Null(),
+			ScaledownPeriod: types.StringNull(),
+			Concurrency:     types.Int64Null(),
+			RequestRate:     types.Int64Null(),
+		}
+		if replicas := autoscaling.GetReplicas(); replicas != nil {
+			model.MinReplicas = types.Int64Value(int64(replicas.GetMin()))
+			model.MaxReplicas = types.Int64Value(int64(replicas.GetMax()))
+		}
+		if period := autoscaling.GetScaledownPeriod(); period != nil {
+			model.ScaledownPeriod = types.StringValue(period.AsDuration().String())
+			if prior, err := time.ParseDuration(priorPeriod.ValueString()); err == nil && prior == period.AsDuration() {
+				model.ScaledownPeriod = priorPeriod
+			}
+		}
+		if metric := autoscaling.GetScalingMetric(); metric != nil {
+			switch {
+			case metric.GetConcurrency() != nil:
+				model.Concurrency = types.Int64Value(int64(metric.GetConcurrency().GetTargetValue()))
+			case metric.GetRequestRate() != nil:
+				model.RequestRate = types.Int64Value(int64(metric.GetRequestRate().GetTargetValue()))
+			}
+		}
+		data.Autoscaling = model
+	}
+
+	var priorPrivate types.Bool
+	if data.Ingress != nil {
+		priorPrivate = data.Ingress.Private
+	}
+	data.Ingress = nil
+	if ingress := spec.GetIngress(); ingress != nil {
+		data.Ingress = &ServingAppIngressModel{
+			Private:   optionalBool(priorPrivate, ingress.GetPrivate()),
+			Subdomain: optionalString(ingress.GetSubdomain()),
+			Cname:     optionalString(ingress.GetCname()),
+		}
+	}
+
+	var priorAnonymous types.Bool
+	var priorSecrets []ServingAppSecretModel
+	if data.SecurityContext != nil {
+		priorAnonymous = data.SecurityContext.AllowAnonymous
+		priorSecrets = data.SecurityContext.Secrets
+	}
+	data.SecurityContext = nil
+	if sc := spec.GetSecurityContext(); sc != nil {
+		model := &ServingAppSecurityContextModel{
+			AllowAnonymous: optionalBool(priorAnonymous, sc.GetAllowAnonymous()),
+		}
+		if runAs := sc.GetRunAs(); runAs != nil {
+			model.RunAs = &ServingAppRunAsModel{
+				IamRole:           optionalString(runAs.GetIamRole()),
+				K8sServiceAccount: optionalString(runAs.GetK8SServiceAccount()),
+			}
+		}
+		for i, s := range sc.GetSecrets() {
+			// The default any mount is read back as unset, unless configured
+			mount := types.StringNull()
+			if s.GetMountRequirement() != core.Secret_ANY {
+				mount = types.StringValue(strings.ToLower(s.GetMountRequirement().String()))
+			} else if i < len(priorSecrets) && priorSecrets[i].MountRequirement.ValueString() == "any" {
+				mount = priorSecrets[i].MountRequirement
+			}
+			model.Secrets = append(model.Secrets, ServingAppSecretModel{
+				Group:            types.StringValue(s.GetGroup()),
+				Key:              optionalString(s.GetKey()),
+				GroupVersion:     optionalString(s.GetGroupVersion()),
+				MountRequirement: mount,
+				EnvVar:           optionalString(s.GetEnvVar()),
+			})
+		}
+		data.SecurityContext = model
+	}
+
+	data.Inputs = nil
+	for _, input := range spec.GetInputs().GetItems() {
+		model := ServingAppInputModel{
+			Name:  types.StringValue(input.GetName()),
+			Value: types.StringNull(),
+			AppId: types.StringNull(),
+		}
+		switch {
+		case input.GetAppId() != nil:
+			model.AppId = types.StringValue(servingAppId(input.GetAppId()))
+		default:
+			// Artifact inputs cannot be configured and surface as empty values
+			model.Value = types.StringValue(input.GetStringValue())
+		}
+		data.Inputs = append(data.Inputs, model)
+	}
+}
+
+// refreshComputed copies only the computed attributes of an app into the
+// model, leaving the planned configuration untouched.
+func (r *ServingAppResource) refreshComputed(data *ServingAppResourceModel, a *flyteapp.App) {
+	data.Id = types.StringValue(servingAppId(r.appId(data)))
+	data.Revision = types.Int64Value(int64(a.GetMetadata().GetRevision()))
+	data.DeploymentStatus = servingAppDeploymentStatus(a.GetStatus())
+	ingress := a.GetStatus().GetIngress()
+	data.PublicUrl = optionalString(ingress.GetPublicUrl())
+	data.CnameUrl = optionalString(ingress.GetCnameUrl())
+	data.VpcUrl = optionalString(ingress.GetVpcUrl())
+	data.CreatedAt = convertTimestampToString(a.GetStatus().GetCreatedAt())
+}
+
+// copyComputed carries the computed attributes over from the prior state.
+func (r *ServingAppResource) copyComputed(data, state *ServingAppResourceModel) {
+	data.Id = state.Id
+	data.Revision = state.Revision
+	data.DeploymentStatus = state.DeploymentStatus
+	data.PublicUrl = state.PublicUrl
+	data.CnameUrl = state.CnameUrl
+	data.VpcUrl = state.VpcUrl
+	data.CreatedAt = state.CreatedAt
+}
+
+func servingAppResourceMap(entries []*core.Resources_ResourceEntry) types.Map {
+	values := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		values[strings.ToLower(entry.GetName().String())] = entry.GetValue()
+	}
+	return convertStringsToMap(values)
+}
+
+// servingAppPodSpec renders a pod spec as JSON, keeping the prior document
+// when it is semantically equal so formatting differences do not show a diff.
+func servingAppPodSpec(prior types.String, podSpec *structpb.Struct) types.String {
+	if podSpec == nil {
+		return types.StringNull()
+	}
+	remote, err := protojson.Marshal(podSpec)
+	if err != nil {
+		return prior
+	}
+	if !prior.IsNull() && !prior.IsUnknown() {
+		var a, b any
+		if json.Unmarshal([]byte(prior.ValueString()), &a) == nil && json.Unmarshal(remote, &b) == nil && reflect.DeepEqual(a, b) {
+			return prior
+		}
+	}
+	return types.StringValue(string(remote))
+}
+
+func (r *ServingAppResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data ServingAppResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := r.spec(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", fmt.Sprintf("Unable to build app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	created, err := r.conn.Create(ctx, &flyteapp.CreateRequest{
+		App: &flyteapp.App{
+			Metadata: &flyteapp.Meta{
+				Id:     r.appId(&data),
+				Labels: convertMapToStrings(data.Labels),
+			},
+			Spec: spec,
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to create app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refreshComputed(&data, created.GetApp())
+
+	if data.WaitForReady.ValueBool() {
+		// The app exists even if it never becomes ready, so it is saved and tainted
+		ready, err := r.waitForReady(ctx, created.GetApp(), r.timeout(&data, "create"))
+		r.refreshComputed(&data, ready)
+		if err != nil {
+			resp.Diagnostics.AddError("App Not Ready", r.waitDiagnostic(ctx, &data, err))
+		}
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServingAppResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data ServingAppResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.Get(ctx, &flyteapp.GetRequest{
+		Identifier: &flyteapp.GetRequest_AppId{AppId: r.appId(&data)},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	r.refresh(&data, got.GetApp())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServingAppResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data ServingAppResourceModel
+	var state ServingAppResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := r.spec(&data)
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid Configuration", fmt.Sprintf("Unable to build app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	// Changing only how Terraform waits does not need a new revision
+	if prior, err := r.spec(&state); err == nil && proto.Equal(prior, spec) && data.Labels.Equal(state.Labels) {
+		r.copyComputed(&data, &state)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	// Updates are optimistically locked on the latest revision
+	latest, err := r.conn.Get(ctx, &flyteapp.GetRequest{
+		Identifier: &flyteapp.GetRequest_AppId{AppId: r.appId(&data)},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+
+	updated, err := r.conn.Update(ctx, &flyteapp.UpdateRequest{
+		App: &flyteapp.App{
+			Metadata: &flyteapp.Meta{
+				Id:       r.appId(&data),
+				Revision: latest.GetApp().GetMetadata().GetRevision(),
+				Labels:   convertMapToStrings(data.Labels),
+			},
+			Spec: spec,
+		},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refreshComputed(&data, updated.GetApp())
+
+	if data.WaitForReady.ValueBool() {
+		ready, err := r.waitForReady(ctx, updated.GetApp(), r.timeout(&data, "update"))
+		r.refreshComputed(&data, ready)
+		if err != nil {
+			resp.Diagnostics.AddError("App Not Ready", r.waitDiagnostic(ctx, &data, err))
+		}
+	}
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *ServingAppResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data ServingAppResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.conn.Delete(ctx, &flyteapp.DeleteRequest{
+		AppId: r.appId(&data),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete app %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+// ImportState accepts an identifier in the form "{project}/{domain}/{name}".
+func (r *ServingAppResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := r.parseAppId(req.ID)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier in the form \"project/domain/name\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), id.GetProject())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), id.GetDomain())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), id.GetName())...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("wait_for_ready"), true)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("failure_log_lines"), int64(defaultServingAppLogLines))...)
+}
+
+// parseAppId parses an app identifier in the form "{project}/{domain}/{name}".
+func (r *ServingAppResource) parseAppId(id string) (*flyteapp.Identifier, error) {
+	parts := strings.Split(id, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return nil, fmt.Errorf("expected an app identifier in the form \"project/domain/name\", got: %q", id)
+	}
+	return &flyteapp.Identifier{
+		Org:     r.org,
+		Project: parts[0],
+		Domain:  parts[1],
+		Name:    parts[2],
+	}, nil
+}
+
+func servingAppId(id *flyteapp.Identifier) string {
+	return strings.Join([]string{id.GetProject(), id.GetDomain(), id.GetName()}, "/")
+}
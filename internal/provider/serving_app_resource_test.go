@@ -0,0 +1,207 @@
+package provider
+
+import (
+	"testing"
+
+	flyteapp "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/app"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/core"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func servingAppModel() *ServingAppResourceModel {
+	return &ServingAppResourceModel{
+		Name:         types.StringValue("server"),
+		Project:      types.StringValue("p"),
+		Domain:       types.StringValue("development"),
+		Labels:       types.MapNull(types.StringType),
+		DesiredState: types.StringValue("active"),
+		ClusterPool:  types.StringNull(),
+		Container: &ServingAppContainerModel{
+			Image:    types.StringValue("ghcr.io/org/server:1.0"),
+			Command:  types.ListNull(types.StringType),
+			Args:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("--port"), types.StringValue("8080")}),
+			Env:      types.MapValueMust(types.StringType, map[string]attr.Value{"LOG_LEVEL": types.StringValue("info")}),
+			Ports:    types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(8080)}),
+			Requests: types.MapValueMust(types.StringType, map[string]attr.Value{"cpu": types.StringValue("1"), "memory": types.StringValue("2Gi")}),
+			Limits:   types.MapNull(types.StringType),
+		},
+		Autoscaling: &ServingAppAutoscalingModel{
+			MinReplicas:     types.Int64Value(0),
+			MaxReplicas:     types.Int64Value(3),
+			ScaledownPeriod: types.StringValue("10m"),
+			Concurrency:     types.Int64Value(10),
+			RequestRate:     types.Int64Null(),
+		},
+		Ingress: &ServingAppIngressModel{
+			Private:   types.BoolNull(),
+			Subdomain: types.StringValue("server"),
+			Cname:     types.StringNull(),
+		},
+		SecurityContext: &ServingAppSecurityContextModel{
+			RunAs: &ServingAppRunAsModel{
+				IamRole:           types.StringNull(),
+				K8sServiceAccount: types.StringValue("server-sa"),
+			},
+			Secrets: []ServingAppSecretModel{{
+				Group:            types.StringValue("hf-token"),
+				Key:              types.StringNull(),
+				GroupVersion:     types.StringNull(),
+				MountRequirement: types.StringValue("env_var"),
+				EnvVar:           types.StringValue("HF_TOKEN"),
+			}},
+			AllowAnonymous: types.BoolNull(),
+		},
+		Inputs: []ServingAppInputModel{
+			{Name: types.StringValue("model"), Value: types.StringValue("s3://models/v1"), AppId: types.StringNull()},
+			{Name: types.StringValue("backend"), Value: types.StringNull(), AppId: types.StringValue("p/development/backend")},
+		},
+	}
+}
+
+func TestServingAppResource_SpecRoundTrip(t *testing.T) {
+	r := &ServingAppResource{org: "test-org"}
+	data := servingAppModel()
+
+	spec, err := r.spec(data)
+	if err != nil {
+		t.Fatalf("spec() returned error: %s", err)
+	}
+	if spec.GetDesiredState() != flyteapp.Spec_DESIRED_STATE_ACTIVE {
+		t.Errorf("Expected desired state active, got %s", spec.GetDesiredState())
+	}
+	if got := spec.GetContainer().GetResources().GetRequests(); len(got) != 2 || got[0].GetName() != core.Resources_CPU {
+		t.Errorf("Expected cpu and memory requests, got %v", got)
+	}
+	if spec.GetAutoscaling().GetScalingMetric().GetConcurrency().GetTargetValue() != 10 {
+		t.Errorf("Expected a concurrency scaling metric, got %v", spec.GetAutoscaling().GetScalingMetric())
+	}
+	if got := spec.GetSecurityContext().GetSecrets(); len(got) != 1 || got[0].GetMountRequirement() != core.Secret_ENV_VAR {
+		t.Errorf("Expected an env var secret, got %v", got)
+	}
+	if got := spec.GetInputs().GetItems()[1].GetAppId(); got.GetOrg() != "test-org" || got.GetName() != "backend" {
+		t.Errorf("Expected the app input to reference backend in the provider org, got %v", got)
+	}
+
+	remote := &flyteapp.App{
+		Metadata: &flyteapp.Meta{
+			Id:       r.appId(data),
+			Revision: 4,
+		},
+		Spec: spec,
+		Status: &flyteapp.Status{
+			Ingress: &flyteapp.Ingress{PublicUrl: "https://server.apps.example.com"},
+			Conditions: []*flyteapp.Condition{
+				{DeploymentStatus: flyteapp.Status_DEPLOYMENT_STATUS_PENDING},
+				{DeploymentStatus: flyteapp.Status_DEPLOYMENT_STATUS_ACTIVE},
+			},
+		},
+	}
+
+	refreshed := servingAppModel()
+	r.refresh(refreshed, remote)
+
+	if refreshed.Id.ValueString() != "p/development/server" || refreshed.Revision.ValueInt64() != 4 {
+		t.Errorf("Unexpected identity after refresh: %s at revision %d", refreshed.Id, refreshed.Revision.ValueInt64())
+	}
+	if refreshed.DeploymentStatus.ValueString() != "active" || refreshed.PublicUrl.ValueString() != "https://server.apps.example.com" {
+		t.Errorf("Unexpected status after refresh: %s at %s", refreshed.DeploymentStatus, refreshed.PublicUrl)
+	}
+	if !refreshed.VpcUrl.IsNull() {
+		t.Errorf("Expected an empty vpc URL to be null, got %s", refreshed.VpcUrl)
+	}
+
+	// The configuration must survive the round trip without a diff
+	expected := servingAppModel()
+	if !refreshed.Container.Args.Equal(expected.Container.Args) ||
+		!refreshed.Container.Env.Equal(expected.Container.Env) ||
+		!refreshed.Container.Ports.Equal(expected.Container.Ports) ||
+		!refreshed.Container.Requests.Equal(expected.Container.Requests) ||
+		!refreshed.Container.Limits.Equal(expected.Container.Limits) {
+		t.Errorf("Container drifted after refresh: %+v", refreshed.Container)
+	}
+	if *refreshed.Autoscaling != *expected.Autoscaling {
+		t.Errorf("Autoscaling drifted after refresh: %+v", refreshed.Autoscaling)
+	}
+	if *refreshed.Ingress != *expected.Ingress {
+		t.Errorf("Ingress drifted after refresh: %+v", refreshed.Ingress)
+	}
+	if *refreshed.SecurityContext.RunAs != *expected.SecurityContext.RunAs || refreshed.SecurityContext.Secrets[0] != expected.SecurityContext.Secrets[0] {
+		t.Errorf("Security context drifted after refresh: %+v", refreshed.SecurityContext)
+	}
+	if len(refreshed.Inputs) != 2 || refreshed.Inputs[0] != expected.Inputs[0] || refreshed.Inputs[1] != expected.Inputs[1] {
+		t.Errorf("Inputs drifted after refresh: %+v", refreshed.Inputs)
+	}
+
+	// An explicit any mount reads back as configured
+	data.SecurityContext.Secrets[0].MountRequirement = types.StringValue("any")
+	if spec, err = r.spec(data); err != nil {
+		t.Fatalf("spec() returned error: %s", err)
+	}
+	remote.Spec = spec
+	r.refresh(data, remote)
+	if got := data.SecurityContext.Secrets[0].MountRequirement; got.ValueString() != "any" {
+		t.Errorf("Expected the any mount to be kept, got %s", got)
+	}
+
+	for _, invalid := range []func(*ServingAppResourceModel){
+		func(m *ServingAppResourceModel) {
+			m.SecurityContext.Secrets[0].MountRequirement = types.StringValue("ENV_VAR")
+		},
+		func(m *ServingAppResourceModel) {
+			m.Container.Requests = types.MapValueMust(types.StringType, map[string]attr.Value{"CPU": types.StringValue("1")})
+		},
+	} {
+		m := servingAppModel()
+		invalid(m)
+		if _, err := r.spec(m); err == nil {
+			t.Errorf("Expected upper-case values to be rejected: %+v", m)
+		}
+	}
+}
+
+func TestServingAppResource_PodSpec(t *testing.T) {
+	r := &ServingAppResource{org: "test-org"}
+	data := servingAppModel()
+	data.Container = nil
+	data.Pod = &ServingAppPodModel{
+		PodSpec:              types.StringValue(`{"containers": [{"name": "server", "image": "nginx"}]}`),
+		PrimaryContainerName: types.StringValue("server"),
+		Labels:               types.MapNull(types.StringType),
+		Annotations:          types.MapNull(types.StringType),
+	}
+
+	spec, err := r.spec(data)
+	if err != nil {
+		t.Fatalf("spec() returned error: %s", err)
+	}
+	if spec.GetPod().GetMetadata() != nil {
+		t.Errorf("Expected no pod metadata without labels or annotations, got %v", spec.GetPod().GetMetadata())
+	}
+
+	refreshed := servingAppModel()
+	refreshed.Pod = data.Pod
+	r.refresh(refreshed, &flyteapp.App{Metadata: &flyteapp.Meta{Id: r.appId(data)}, Spec: spec})
+	if refreshed.Container != nil {
+		t.Errorf("Expected the container payload to be cleared, got %+v", refreshed.Container)
+	}
+	if !refreshed.Pod.PodSpec.Equal(data.Pod.PodSpec) {
+		t.Errorf("Expected an equivalent pod spec to keep its configured form, got %s", refreshed.Pod.PodSpec)
+	}
+
+	data.Pod.PodSpec = types.StringValue("not json")
+	if _, err := r.spec(data); err == nil {
+		t.Error("Expected an invalid pod spec to be rejected")
+	}
+}
+
+func TestServingAppDesiredState(t *testing.T) {
+	if state, err := servingAppDesiredState("stopped"); err != nil || state != flyteapp.Spec_DESIRED_STATE_STOPPED {
+		t.Errorf("Expected stopped, got %s (%v)", state, err)
+	}
+	for _, value := range []string{"", "unspecified", "running", "started", "ACTIVE"} {
+		if _, err := servingAppDesiredState(value); err == nil {
+			t.Errorf("Expected desired state %q to be rejected", value)
+		}
+	}
+}
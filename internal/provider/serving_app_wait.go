@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	flyteapp "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/app"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultServingAppTimeout bounds how long create and update wait for an app
+// to become ready.
+const defaultServingAppTimeout = 20 * time.Minute
+
+// defaultServingAppLogLines is the number of log lines included when a
+// rollout fails.
+const defaultServingAppLogLines = 50
+
+// servingAppLogTailTimeout bounds how long log lines are collected. TailLogs
+// keeps streaming new lines, so the stream is cut off once it goes quiet.
+var servingAppLogTailTimeout = 10 * time.Second
+
+// servingAppWatchRetryDelay is the pause before the watch stream is reopened
+// after it ended without the app becoming ready.
+var servingAppWatchRetryDelay = 2 * time.Second
+
+// ServingAppFailedError is returned when a rollout reports a failed
+// deployment status.
+type ServingAppFailedError struct {
+	Revision uint64
+	Message  string
+	Actor    string
+}
+
+func (e *ServingAppFailedError) Error() string {
+	msg := fmt.Sprintf("revision %d failed to deploy", e.Revision)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Actor != "" {
+		msg += fmt.Sprintf(" (reported by %s)", e.Actor)
+	}
+	return msg
+}
+
+// ServingAppTimeoutError is returned when an app does not become ready in
+// time. Status is the last deployment status seen.
+type ServingAppTimeoutError struct {
+	Timeout time.Duration
+	Status  string
+	Message string
+}
+
+func (e *ServingAppTimeoutError) Error() string {
+	msg := fmt.Sprintf("app did not become ready within %s", e.Timeout)
+	if e.Status != "" {
+		msg += fmt.Sprintf(", last status %s", e.Status)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	return msg
+}
+
+// servingAppReadiness evaluates the conditions of an app for the given
+// revision. Conditions of older revisions are ignored; conditions without a
+// revision are assumed to describe the latest one. An active app is ready
+// while it scales, and an app desired to be stopped is ready once it is
+// stopped.
+func servingAppReadiness(a *flyteapp.App, revision uint64) (ready bool, latest *flyteapp.Condition, err error) {
+	for _, condition := range a.GetStatus().GetConditions() {
+		if condition.GetRevision() == 0 || condition.GetRevision() >= revision {
+			latest = condition
+		}
+	}
+	if latest == nil {
+		return false, nil, nil
+	}
+
+	switch latest.GetDeploymentStatus() {
+	case flyteapp.Status_DEPLOYMENT_STATUS_FAILED:
+		return false, latest, &ServingAppFailedError{
+			Revision: revision,
+			Message:  latest.GetMessage(),
+			Actor:    servingAppActor(latest),
+		}
+	case flyteapp.Status_DEPLOYMENT_STATUS_STOPPED:
+		return a.GetSpec().GetDesiredState() == flyteapp.Spec_DESIRED_STATE_STOPPED, latest, nil
+	case flyteapp.Status_DEPLOYMENT_STATUS_ACTIVE, flyteapp.Status_DEPLOYMENT_STATUS_STARTED,
+		flyteapp.Status_DEPLOYMENT_STATUS_SCALING_UP, flyteapp.Status_DEPLOYMENT_STATUS_SCALING_DOWN:
+		// An autoscaling app keeps serving while its replica count changes
+		return a.GetSpec().GetDesiredState() != flyteapp.Spec_DESIRED_STATE_STOPPED, latest, nil
+	}
+	return false, latest, nil
+}
+
+func servingAppActor(condition *flyteapp.Condition) string {
+	actor := condition.GetActor()
+	switch {
+	case actor.GetUser() != nil:
+		if email := actor.GetUser().GetSpec().GetEmail(); email != "" {
+			return email
+		}
+		return actor.GetUser().GetId().GetSubject()
+	case actor.GetApplication() != nil:
+		return actor.GetApplication().GetId().GetSubject()
+	}
+	return ""
+}
+
+// waitForReady blocks until the given revision of an app is ready, fails or
+// the timeout expires. It starts from the app returned by the create or
+// update call and follows the Watch stream, reopening it if it ends early.
+// The last app seen is returned along with any error.
+func (r *ServingAppResource) waitForReady(ctx context.Context, a *flyteapp.App, timeout time.Duration) (*flyteapp.App, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	id := a.GetMetadata().GetId()
+	revision := a.GetMetadata().GetRevision()
+	last := a
+
+	timedOut := func() error {
+		timeoutErr := &ServingAppTimeoutError{Timeout: timeout}
+		if _, condition, _ := servingAppReadiness(last, revision); condition != nil {
+			timeoutErr.Status = servingAppDeploymentStatus(last.GetStatus()).ValueString()
+			timeoutErr.Message = condition.GetMessage()
+		}
+		return timeoutErr
+	}
+
+	for {
+		ready, _, err := servingAppReadiness(last, revision)
+		if err != nil || ready {
+			return last, err
+		}
+
+		stream, err := r.conn.Watch(ctx, &flyteapp.WatchRequest{
+			Target: &flyteapp.WatchRequest_AppId{AppId: id},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, timedOut()
+			}
+			return last, fmt.Errorf("unable to watch app: %w", err)
+		}
+
+		for {
+			resp, err := stream.Recv()
+			if err != nil {
+				if ctx.Err() != nil {
+					return last, timedOut()
+				}
+				if errors.Is(err, io.EOF) || status.Code(err) == codes.Unavailable {
+					break
+				}
+				return last, fmt.Errorf("unable to watch app: %w", err)
+			}
+
+			switch {
+			case resp.GetDeleteEvent() != nil:
+				return last, fmt.Errorf("app was deleted while waiting for it to become ready")
+			case resp.GetCreateEvent() != nil:
+				last = resp.GetCreateEvent().GetApp()
+			case resp.GetUpdateEvent() != nil:
+				last = resp.GetUpdateEvent().GetUpdatedApp()
+			default:
+				continue
+			}
+
+			ready, _, err := servingAppReadiness(last, revision)
+			if err != nil || ready {
+				return last, err
+			}
+		}
+
+		// The stream ended early; catch up on missed events before reopening it
+		select {
+		case <-ctx.Done():
+			return last, timedOut()
+		case <-time.After(servingAppWatchRetryDelay):
+		}
+		got, err := r.conn.Get(ctx, &flyteapp.GetRequest{
+			Identifier: &flyteapp.GetRequest_AppId{AppId: id},
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, timedOut()
+			}
+			return last, fmt.Errorf("unable to read app: %w", err)
+		}
+		last = got.GetApp()
+	}
+}
+
+// tailLogs returns up to n of the most recent log lines of an app. Errors are
+// swallowed, as logs only add context to a failed rollout.
+func (r *ServingAppResource) tailLogs(ctx context.Context, id *flyteapp.Identifier, n int) []string {
+	if r.logs == nil || n <= 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, servingAppLogTailTimeout)
+	defer cancel()
+
+	stream, err := r.logs.TailLogs(ctx, &flyteapp.TailLogsRequest{
+		Target: &flyteapp.TailLogsRequest_AppId{AppId: id},
+	})
+	if err != nil {
+		return nil
+	}
+
+	var lines []string
+	add := func(batch []string) {
+		lines = append(lines, batch...)
+		if len(lines) > n {
+			lines = lines[len(lines)-n:]
+		}
+	}
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return lines
+		}
+		if logLines := resp.GetLogLines(); logLines != nil {
+			add(logLines.GetLines())
+			for _, line := range logLines.GetStructuredLines() {
+				add([]string{line.GetMessage()})
+			}
+		}
+		for _, logLines := range resp.GetBatches().GetLogs() {
+			add(logLines.GetLines())
+			for _, line := range logLines.GetStructuredLines() {
+				add([]string{line.GetMessage()})
+			}
+		}
+	}
+}
+
+// waitDiagnostic renders the detail of a failed wait, including the most
+// recent log lines of the app.
+func (r *ServingAppResource) waitDiagnostic(ctx context.Context, data *ServingAppResourceModel, err error) string {
+	detail := fmt.Sprintf("App %s is not ready: %s", data.Name.ValueString(), err)
+
+	var failed *ServingAppFailedError
+	var timedOut *ServingAppTimeoutError
+	if !errors.As(err, &failed) && !errors.As(err, &timedOut) {
+		return detail
+	}
+	lines := r.tailLogs(ctx, r.appId(data), int(data.FailureLogLines.ValueInt64()))
+	if len(lines) == 0 {
+		return detail
+	}
+	return fmt.Sprintf("%s\n\nLast %d log lines:\n%s", detail, len(lines), strings.Join(lines, "\n"))
+}
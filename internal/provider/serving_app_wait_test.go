@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	flyteapp "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/app"
+	flytecommon "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/common"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/logs/dataplane"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc"
+)
+
+// mockAppClient implements the subset of app.AppServiceClient used while
+// waiting for an app. Each Watch call replays the next batch of events.
+type mockAppClient struct {
+	flyteapp.AppServiceClient
+	watches [][]*flyteapp.WatchResponse
+	current *flyteapp.App
+}
+
+func (m *mockAppClient) Watch(ctx context.Context, in *flyteapp.WatchRequest, opts ...grpc.CallOption) (flyteapp.AppService_WatchClient, error) {
+	stream := &mockWatchStream{ctx: ctx}
+	if len(m.watches) > 0 {
+		stream.events = m.watches[0]
+		m.watches = m.watches[1:]
+	} else {
+		stream.block = true
+	}
+	return stream, nil
+}
+
+func (m *mockAppClient) Get(ctx context.Context, in *flyteapp.GetRequest, opts ...grpc.CallOption) (*flyteapp.GetResponse, error) {
+	return &flyteapp.GetResponse{App: m.current}, nil
+}
+
+type mockWatchStream struct {
+	grpc.ClientStream
+	ctx    context.Context
+	events []*flyteapp.WatchResponse
+	block  bool
+}
+
+func (s *mockWatchStream) Recv() (*flyteapp.WatchResponse, error) {
+	if len(s.events) > 0 {
+		event := s.events[0]
+		s.events = s.events[1:]
+		return event, nil
+	}
+	if s.block {
+		<-s.ctx.Done()
+		return nil, s.ctx.Err()
+	}
+	return nil, io.EOF
+}
+
+type mockAppLogsClient struct {
+	flyteapp.AppLogsServiceClient
+	responses []*flyteapp.TailLogsResponse
+}
+
+func (m *mockAppLogsClient) TailLogs(ctx context.Context, in *flyteapp.TailLogsRequest, opts ...grpc.CallOption) (flyteapp.AppLogsService_TailLogsClient, error) {
+	return &mockTailLogsStream{ctx: ctx, responses: m.responses}, nil
+}
+
+type mockTailLogsStream struct {
+	grpc.ClientStream
+	ctx       context.Context
+	responses []*flyteapp.TailLogsResponse
+}
+
+func (s *mockTailLogsStream) Recv() (*flyteapp.TailLogsResponse, error) {
+	if len(s.responses) > 0 {
+		resp := s.responses[0]
+		s.responses = s.responses[1:]
+		return resp, nil
+	}
+	// Tailing never ends on its own
+	<-s.ctx.Done()
+	return nil, s.ctx.Err()
+}
+
+func servingAppAt(revision uint64, statuses ...flyteapp.Status_DeploymentStatus) *flyteapp.App {
+	a := &flyteapp.App{
+		Metadata: &flyteapp.Meta{
+			Id:       &flyteapp.Identifier{Org: "test-org", Project: "p", Domain: "development", Name: "server"},
+			Revision: revision,
+		},
+		Spec:   &flyteapp.Spec{DesiredState: flyteapp.Spec_DESIRED_STATE_ACTIVE},
+		Status: &flyteapp.Status{},
+	}
+	for _, s := range statuses {
+		a.Status.Conditions = append(a.Status.Conditions, &flyteapp.Condition{DeploymentStatus: s, Revision: revision})
+	}
+	return a
+}
+
+func updateEvent(a *flyteapp.App) *flyteapp.WatchResponse {
+	return &flyteapp.WatchResponse{
+		Event: &flyteapp.WatchResponse_UpdateEvent{UpdateEvent: &flyteapp.UpdateEvent{UpdatedApp: a}},
+	}
+}
+
+func TestServingAppReadiness(t *testing.T) {
+	// An active condition of the previous revision does not count
+	a := servingAppAt(2, flyteapp.Status_DEPLOYMENT_STATUS_DEPLOYING)
+	a.Status.Conditions = append([]*flyteapp.Condition{{DeploymentStatus: flyteapp.Status_DEPLOYMENT_STATUS_ACTIVE, Revision: 1}}, a.Status.Conditions...)
+	if ready, _, err := servingAppReadiness(a, 2); ready || err != nil {
+		t.Errorf("Expected revision 2 to still be deploying, got ready=%t err=%v", ready, err)
+	}
+
+	a = servingAppAt(2, flyteapp.Status_DEPLOYMENT_STATUS_FAILED)
+	a.Status.Conditions[0].Message = "image pull failed"
+	a.Status.Conditions[0].Actor = &flytecommon.EnrichedIdentity{
+		Principal: &flytecommon.EnrichedIdentity_User{User: &flytecommon.User{Spec: &flytecommon.UserSpec{Email: "jane@example.com"}}},
+	}
+	_, _, err := servingAppReadiness(a, 2)
+	var failed *ServingAppFailedError
+	if !errors.As(err, &failed) || failed.Message != "image pull failed" || failed.Actor != "jane@example.com" {
+		t.Errorf("Expected a failed rollout with its message and actor, got %v", err)
+	}
+
+	a = servingAppAt(3, flyteapp.Status_DEPLOYMENT_STATUS_STOPPED)
+	a.Spec.DesiredState = flyteapp.Spec_DESIRED_STATE_STOPPED
+	if ready, _, _ := servingAppReadiness(a, 3); !ready {
+		t.Error("Expected a stopped app to be ready when it is desired to be stopped")
+	}
+
+	for _, tc := range []struct {
+		status  flyteapp.Status_DeploymentStatus
+		desired flyteapp.Spec_DesiredState
+		ready   bool
+	}{
+		{flyteapp.Status_DEPLOYMENT_STATUS_ACTIVE, flyteapp.Spec_DESIRED_STATE_ACTIVE, true},
+		{flyteapp.Status_DEPLOYMENT_STATUS_STARTED, flyteapp.Spec_DESIRED_STATE_ACTIVE, true},
+		{flyteapp.Status_DEPLOYMENT_STATUS_SCALING_UP, flyteapp.Spec_DESIRED_STATE_ACTIVE, true},
+		{flyteapp.Status_DEPLOYMENT_STATUS_SCALING_DOWN, flyteapp.Spec_DESIRED_STATE_ACTIVE, true},
+		{flyteapp.Status_DEPLOYMENT_STATUS_SCALING_DOWN, flyteapp.Spec_DESIRED_STATE_STOPPED, false},
+		{flyteapp.Status_DEPLOYMENT_STATUS_STOPPED, flyteapp.Spec_DESIRED_STATE_ACTIVE, false},
+		{flyteapp.Status_DEPLOYMENT_STATUS_DEPLOYING, flyteapp.Spec_DESIRED_STATE_ACTIVE, false},
+	} {
+		a := servingAppAt(4, tc.status)
+		a.Spec.DesiredState = tc.desired
+		if ready, _, err := servingAppReadiness(a, 4); ready != tc.ready || err != nil {
+			t.Errorf("servingAppReadiness(%s, %s) = %t, %v, expected %t", tc.status, tc.desired, ready, err, tc.ready)
+		}
+	}
+}
+
+func TestServingAppResource_WaitForReady(t *testing.T) {
+	defer func(delay time.Duration) { servingAppWatchRetryDelay = delay }(servingAppWatchRetryDelay)
+	servingAppWatchRetryDelay = 0
+	client := &mockAppClient{
+		watches: [][]*flyteapp.WatchResponse{
+			// The first stream ends before the rollout completes
+			{updateEvent(servingAppAt(2, flyteapp.Status_DEPLOYMENT_STATUS_PENDING))},
+			{
+				updateEvent(servingAppAt(2, flyteapp.Status_DEPLOYMENT_STATUS_PENDING, flyteapp.Status_DEPLOYMENT_STATUS_DEPLOYING)),
+				updateEvent(servingAppAt(2, flyteapp.Status_DEPLOYMENT_STATUS_DEPLOYING, flyteapp.Status_DEPLOYMENT_STATUS_ACTIVE)),
+			},
+		},
+		current: servingAppAt(2, flyteapp.Status_DEPLOYMENT_STATUS_PENDING),
+	}
+	r := &ServingAppResource{conn: client, org: "test-org"}
+
+	ready, err := r.waitForReady(context.Background(), servingAppAt(2), time.Second)
+	if err != nil {
+		t.Fatalf("waitForReady() returned error: %s", err)
+	}
+	if got := servingAppDeploymentStatus(ready.GetStatus()).ValueString(); got != "active" {
+		t.Errorf("Expected the app to end up active, got %s", got)
+	}
+
+	// Nothing happens until the timeout
+	client.current = servingAppAt(3, flyteapp.Status_DEPLOYMENT_STATUS_PENDING)
+	_, err = r.waitForReady(context.Background(), client.current, 50*time.Millisecond)
+	var timedOut *ServingAppTimeoutError
+	if !errors.As(err, &timedOut) || timedOut.Status != "pending" {
+		t.Errorf("Expected a timeout in the pending status, got %v", err)
+	}
+}
+
+func TestServingAppResource_WaitDiagnostic(t *testing.T) {
+	defer func(timeout time.Duration) { servingAppLogTailTimeout = timeout }(servingAppLogTailTimeout)
+	servingAppLogTailTimeout = 50 * time.Millisecond
+	r := &ServingAppResource{
+		org: "test-org",
+		logs: &mockAppLogsClient{responses: []*flyteapp.TailLogsResponse{
+			{Resp: &flyteapp.TailLogsResponse_LogLines{LogLines: &dataplane.LogLines{Lines: []string{"starting", "loading model"}}}},
+			{Resp: &flyteapp.TailLogsResponse_LogLines{LogLines: &dataplane.LogLines{Lines: []string{"out of memory"}}}},
+		}},
+	}
+	data := servingAppModel()
+	data.FailureLogLines = types.Int64Value(2)
+
+	detail := r.waitDiagnostic(context.Background(), data, &ServingAppFailedError{Revision: 2, Message: "crash loop"})
+	if !strings.Contains(detail, "crash loop") || !strings.HasSuffix(detail, "Last 2 log lines:\nloading model\nout of memory") {
+		t.Errorf("Expected the failure and the last two log lines, got %q", detail)
+	}
+
+	data.FailureLogLines = types.Int64Value(0)
+	if detail := r.waitDiagnostic(context.Background(), data, &ServingAppFailedError{Revision: 2}); strings.Contains(detail, "log lines") {
+		t.Errorf("Expected no logs when disabled, got %q", detail)
+	}
+}
@@ -0,0 +1,182 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// defaultSourceIncludes selects the Python sources of an environment along
+// with the files that pin its dependencies.
+var defaultSourceIncludes = []string{
+	"**/*.py",
+	"requirements*.txt",
+	"pyproject.toml",
+	"setup.cfg",
+	"setup.py",
+	"uv.lock",
+	"poetry.lock",
+	"Pipfile",
+	"Pipfile.lock",
+}
+
+// defaultSourceExcludes skips version control metadata, virtualenvs and
+// bytecode caches.
+var defaultSourceExcludes = []string{
+	".git/**",
+	".venv/**",
+	"venv/**",
+	"**/__pycache__/**",
+	"**/*.pyc",
+}
+
+// globToRegexp converts a glob to a regular expression. "**" matches any
+// number of path segments, "*" and "?" match within a single segment.
+// Patterns without a slash match the base name at any depth.
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	pattern = filepath.ToSlash(pattern)
+	if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch {
+		case c == '*' && i+1 < len(pattern) && pattern[i+1] == '*':
+			i++
+			if i+1 < len(pattern) && pattern[i+1] == '/' {
+				// "**/" also matches no directory at all
+				i++
+				b.WriteString("(?:.*/)?")
+			} else {
+				b.WriteString(".*")
+			}
+		case c == '*':
+			b.WriteString("[^/]*")
+		case c == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
+
+func compileGlobs(patterns []string) ([]*regexp.Regexp, error) {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := globToRegexp(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		res = append(res, re)
+	}
+	return res, nil
+}
+
+func matchAny(globs []*regexp.Regexp, rel string) bool {
+	for _, re := range globs {
+		if re.MatchString(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// hashSourceDir computes a content hash of the files under dir that match
+// one of the include globs and none of the exclude globs. Paths are relative
+// to dir, so the hash does not depend on where the tree is checked out.
+func hashSourceDir(dir string, includes, excludes []string) (string, error) {
+	if len(includes) == 0 {
+		includes = defaultSourceIncludes
+	}
+	if excludes == nil {
+		excludes = defaultSourceExcludes
+	}
+	include, err := compileGlobs(includes)
+	if err != nil {
+		return "", err
+	}
+	exclude, err := compileGlobs(excludes)
+	if err != nil {
+		return "", err
+	}
+
+	var files []string
+	err = filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+		if d.IsDir() {
+			// Skip excluded directories without walking them
+			if rel != "." && matchAny(exclude, rel+"/") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if matchAny(include, rel) && !matchAny(exclude, rel) {
+			files = append(files, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(files) == 0 {
+		return "", fmt.Errorf("no source files in %s match the include globs", dir)
+	}
+	sort.Strings(files)
+
+	h := sha256.New()
+	for _, rel := range files {
+		fmt.Fprintf(h, "%s\x00", rel)
+		if err := hashFileInto(h, filepath.Join(dir, filepath.FromSlash(rel))); err != nil {
+			return "", err
+		}
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// combineHashes computes a single hash from several content hashes.
+func combineHashes(hashes ...string) string {
+	h := sha256.New()
+	for _, hash := range hashes {
+		fmt.Fprintf(h, "%s\x00", hash)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashSourceFile computes the content hash of a single file.
+func hashSourceFile(path string) (string, error) {
+	h := sha256.New()
+	if err := hashFileInto(h, path); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+func hashFileInto(w io.Writer, path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(w, f)
+	return err
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSourceTree(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatalf("Failed to create directory: %s", err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write file: %s", err)
+		}
+	}
+	return dir
+}
+
+func TestGlobToRegexp(t *testing.T) {
+	for _, tc := range []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"**/*.py", "main.py", true},
+		{"**/*.py", "pkg/sub/mod.py", true},
+		{"**/*.py", "pkg/mod.pyc", false},
+		{"*.py", "pkg/mod.py", true},
+		{"src/*.py", "src/mod.py", true},
+		{"src/*.py", "src/pkg/mod.py", false},
+		{"requirements*.txt", "requirements-dev.txt", true},
+		{".git/**", ".git/", true},
+		{"**/__pycache__/**", "pkg/__pycache__/mod.cpython-312.pyc", true},
+		{"uv.lock", "uv_lock", false},
+	} {
+		re, err := globToRegexp(tc.pattern)
+		if err != nil {
+			t.Fatalf("globToRegexp(%q) returned error: %s", tc.pattern, err)
+		}
+		if got := re.MatchString(tc.path); got != tc.match {
+			t.Errorf("Expected %q matching %q to be %t", tc.pattern, tc.path, tc.match)
+		}
+	}
+}
+
+func TestHashSourceDir(t *testing.T) {
+	files := map[string]string{
+		"main.py":                  "print('hello')",
+		"uv.lock":                  "lock",
+		"README.md":                "docs",
+		"pkg/__pycache__/main.pyc": "bytecode",
+	}
+	dir := writeSourceTree(t, files)
+	hash, err := hashSourceDir(dir, nil, nil)
+	if err != nil {
+		t.Fatalf("hashSourceDir() returned error: %s", err)
+	}
+
+	// Files outside the include globs do not affect the hash
+	files["README.md"] = "more docs"
+	files["pkg/__pycache__/main.pyc"] = "other bytecode"
+	if other, _ := hashSourceDir(writeSourceTree(t, files), nil, nil); other != hash {
+		t.Errorf("Expected unrelated changes to keep the hash %s, got %s", hash, other)
+	}
+
+	files["uv.lock"] = "new lock"
+	if other, _ := hashSourceDir(writeSourceTree(t, files), nil, nil); other == hash {
+		t.Error("Expected a lockfile change to change the hash")
+	}
+
+	// Custom globs replace the defaults
+	files["uv.lock"] = "lock"
+	if other, _ := hashSourceDir(writeSourceTree(t, files), []string{"**/*.py"}, []string{"main.py"}); other != "" {
+		t.Errorf("Expected no files to match, got hash %s", other)
+	}
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"google.golang.org/protobuf/encoding/protodelim"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// loadTaskBundle reads a serialized task spec bundle. A bundle is a list of
+// DeployTaskRequest messages, stored either as JSON (a single request or an
+// array of requests, in the protobuf JSON mapping) or as a stream of
+// size-delimited binary messages. Files ending in ".json" are parsed as JSON,
+// everything else as binary.
+func loadTaskBundle(path string) ([]*task.DeployTaskRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var reqs []*task.DeployTaskRequest
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		reqs, err = parseTaskBundleJSON(data)
+	} else {
+		reqs, err = parseTaskBundleBinary(data)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse task spec bundle %s: %w", path, err)
+	}
+	if len(reqs) == 0 {
+		return nil, fmt.Errorf("task spec bundle %s contains no tasks", path)
+	}
+	return reqs, nil
+}
+
+func parseTaskBundleJSON(data []byte) ([]*task.DeployTaskRequest, error) {
+	var raw []json.RawMessage
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, err
+		}
+	} else {
+		raw = []json.RawMessage{data}
+	}
+
+	reqs := make([]*task.DeployTaskRequest, 0, len(raw))
+	for i, item := range raw {
+		req := &task.DeployTaskRequest{}
+		if err := protojson.Unmarshal(item, req); err != nil {
+			return nil, fmt.Errorf("task %d: %w", i, err)
+		}
+		reqs = append(reqs, req)
+	}
+	return reqs, nil
+}
+
+func parseTaskBundleBinary(data []byte) ([]*task.DeployTaskRequest, error) {
+	reader := bufio.NewReader(bytes.NewReader(data))
+	var reqs []*task.DeployTaskRequest
+	for {
+		req := &task.DeployTaskRequest{}
+		if err := protodelim.UnmarshalFrom(reader, req); err != nil {
+			if errors.Is(err, io.EOF) {
+				return reqs, nil
+			}
+			return nil, fmt.Errorf("task %d: %w", len(reqs), err)
+		}
+		reqs = append(reqs, req)
+	}
+}
+
+// taskBundleDetails derives the environment name, version and task names
+// from a task spec bundle. The version and name come from the first task; all
+// tasks of a bundle must share the same version.
+func taskBundleDetails(reqs []*task.DeployTaskRequest) (*FlyteEnvironmentDetails, error) {
+	details := &FlyteEnvironmentDetails{}
+	for i, req := range reqs {
+		id := req.GetTaskId()
+		if id.GetName() == "" || id.GetVersion() == "" {
+			return nil, fmt.Errorf("task %d of the bundle is missing a name or version", i)
+		}
+		if i == 0 {
+			details.Name = req.GetSpec().GetEnvironment().GetName()
+			details.Version = id.GetVersion()
+			if details.Name == "" {
+				// Task names are prefixed with their environment, e.g. "hello_world.main"
+				details.Name, _, _ = strings.Cut(id.GetName(), ".")
+			}
+		} else if id.GetVersion() != details.Version {
+			return nil, fmt.Errorf("task %s has version %s, expected all tasks of the bundle to have version %s", id.GetName(), id.GetVersion(), details.Version)
+		}
+		details.Tasks = append(details.Tasks, id.GetName())
+	}
+	return details, nil
+}
@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"google.golang.org/protobuf/encoding/protodelim"
+)
+
+func writeTaskBundle(t *testing.T, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("Failed to write bundle: %s", err)
+	}
+	return path
+}
+
+func TestLoadTaskBundle_JSON(t *testing.T) {
+	path := writeTaskBundle(t, "bundle.json", []byte(`[
+		{"taskId": {"name": "hello_world.main", "version": "abc123"}, "spec": {"environment": {"name": "hello_world"}}},
+		{"taskId": {"name": "hello_world.fn", "version": "abc123"}}
+	]`))
+
+	reqs, err := loadTaskBundle(path)
+	if err != nil {
+		t.Fatalf("loadTaskBundle() returned error: %s", err)
+	}
+	details, err := taskBundleDetails(reqs)
+	if err != nil {
+		t.Fatalf("taskBundleDetails() returned error: %s", err)
+	}
+	if details.Name != "hello_world" || details.Version != "abc123" {
+		t.Errorf("Expected hello_world at abc123, got %s at %s", details.Name, details.Version)
+	}
+	if len(details.Tasks) != 2 || details.Tasks[1] != "hello_world.fn" {
+		t.Errorf("Expected two tasks, got %v", details.Tasks)
+	}
+
+	// A single request is accepted as well
+	path = writeTaskBundle(t, "single.json", []byte(`{"taskId": {"name": "env.main", "version": "v1"}}`))
+	reqs, err = loadTaskBundle(path)
+	if err != nil {
+		t.Fatalf("loadTaskBundle() returned error: %s", err)
+	}
+	details, err = taskBundleDetails(reqs)
+	if err != nil {
+		t.Fatalf("taskBundleDetails() returned error: %s", err)
+	}
+	if details.Name != "env" {
+		t.Errorf("Expected the environment name to be derived from the task name, got %s", details.Name)
+	}
+}
+
+func TestLoadTaskBundle_Binary(t *testing.T) {
+	var buf bytes.Buffer
+	for _, name := range []string{"env.a", "env.b"} {
+		if _, err := protodelim.MarshalTo(&buf, &task.DeployTaskRequest{
+			TaskId: &task.TaskIdentifier{Name: name, Version: "v2"},
+		}); err != nil {
+			t.Fatalf("Failed to marshal request: %s", err)
+		}
+	}
+
+	reqs, err := loadTaskBundle(writeTaskBundle(t, "bundle.pb", buf.Bytes()))
+	if err != nil {
+		t.Fatalf("loadTaskBundle() returned error: %s", err)
+	}
+	if len(reqs) != 2 || reqs[1].GetTaskId().GetName() != "env.b" {
+		t.Errorf("Expected two requests, got %v", reqs)
+	}
+}
+
+func TestLoadTaskBundle_Invalid(t *testing.T) {
+	if _, err := loadTaskBundle(writeTaskBundle(t, "empty.json", []byte(`[]`))); err == nil {
+		t.Error("Expected an empty bundle to be rejected")
+	}
+	if _, err := loadTaskBundle(writeTaskBundle(t, "bad.json", []byte(`{"bogus": true}`))); err == nil {
+		t.Error("Expected unknown fields to be rejected")
+	}
+
+	_, err := taskBundleDetails([]*task.DeployTaskRequest{
+		{TaskId: &task.TaskIdentifier{Name: "env.a", Version: "v1"}},
+		{TaskId: &task.TaskIdentifier{Name: "env.b", Version: "v2"}},
+	})
+	if err == nil {
+		t.Error("Expected mixed versions to be rejected")
+	}
+}
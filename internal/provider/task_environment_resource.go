@@ -4,39 +4,71 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"slices"
+	"strings"
 
+	flytecommon "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/common"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/trigger"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Ensure provider defined types fully satisfy framework interfaces.
 var _ resource.Resource = &TaskEnvironmentResource{}
 var _ resource.ResourceWithImportState = &TaskEnvironmentResource{}
 var _ resource.ResourceWithModifyPlan = &TaskEnvironmentResource{}
+var _ resource.ResourceWithValidateConfig = &TaskEnvironmentResource{}
 
 func NewTaskEnvironmentResource() resource.Resource {
 	return &TaskEnvironmentResource{}
 }
 
-// TaskEnvironmentResource defines the resource implementation.
+const (
+	// taskEnvironmentRetain leaves the deployed tasks in place on destroy.
+	taskEnvironmentRetain = "retain"
+	// taskEnvironmentDeactivate deactivates the triggers of the deployed
+	// tasks on destroy.
+	taskEnvironmentDeactivate = "deactivate"
+)
+
+// TaskEnvironmentResource defines the resource implementation. Environments
+// are either deployed by the flyte CLI from a Python file (path), or
+// registered natively through TaskService from a serialized task spec bundle
+// (spec_file).
 type TaskEnvironmentResource struct {
-	flyte FlyteEnvironment
+	flyte    FlyteEnvironment
+	cli      FlyteCLIConfig
+	conn     task.TaskServiceClient
+	triggers trigger.TriggerServiceClient
+	org      string
 }
 
 // TaskEnvironmentResourceModel describes the resource data model.
 type TaskEnvironmentResourceModel struct {
-	Id      types.String `tfsdk:"id"`
-	Name    types.String `tfsdk:"name"`
-	Path    types.String `tfsdk:"path"`
-	Project types.String `tfsdk:"project"`
-	Domain  types.String `tfsdk:"domain"`
-	Version types.String `tfsdk:"version"`
-	Tasks   types.List   `tfsdk:"tasks"`
+	Id         types.String   `tfsdk:"id"`
+	Name       types.String   `tfsdk:"name"`
+	Path       types.String   `tfsdk:"path"`
+	SpecFile   types.String   `tfsdk:"spec_file"`
+	Project    types.String   `tfsdk:"project"`
+	Domain     types.String   `tfsdk:"domain"`
+	Version    types.String   `tfsdk:"version"`
+	Tasks      types.List     `tfsdk:"tasks"`
+	SourceDir  types.String   `tfsdk:"source_dir"`
+	Include    types.List     `tfsdk:"include"`
+	Exclude    types.List     `tfsdk:"exclude"`
+	SourceHash types.String   `tfsdk:"source_hash"`
+	OnDestroy  types.String   `tfsdk:"on_destroy"`
+	FlyteCLI   *FlyteCLIModel `tfsdk:"flyte_cli"`
 }
 
 func (r *TaskEnvironmentResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -61,8 +93,12 @@ func (r *TaskEnvironmentResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "Name of the task environment",
 			},
 			"path": schema.StringAttribute{
-				Required:            true,
-				MarkdownDescription: "This points to the task Python file.",
+				Optional:            true,
+				MarkdownDescription: "This points to the task Python file, deployed with the flyte CLI. Conflicts with `spec_file`.",
+			},
+			"spec_file": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Serialized task spec bundle, deployed natively through the task service without the flyte CLI. Files ending in `.json` hold a `DeployTaskRequest` or an array of them in the protobuf JSON mapping; any other file holds size-delimited binary `DeployTaskRequest` messages. Conflicts with `path`.",
 			},
 			"project": schema.StringAttribute{
 				Required:            true,
@@ -87,6 +123,53 @@ func (r *TaskEnvironmentResource) Schema(ctx context.Context, req resource.Schem
 				MarkdownDescription: "List of tasks in the environment",
 				ElementType:         types.StringType,
 			},
+			"source_dir": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Directory holding the sources of the environment. Its content hash decides when the version is recalculated. If unset, only the `path` or `spec_file` file is hashed, and the version of a `path` environment is recalculated on every plan.",
+			},
+			"include": schema.ListAttribute{
+				Optional:            true,
+				MarkdownDescription: "Globs, relative to `source_dir`, of the files that make up the environment. `**` matches any number of directories, and globs without a slash match file names at any depth. Defaults to Python sources, requirements files and lockfiles.",
+				ElementType:         types.StringType,
+			},
+			"exclude": schema.ListAttribute{
+				Optional:            true,
+				MarkdownDescription: "Globs, relative to `source_dir`, of files to leave out of the hash. Defaults to `.git`, virtualenvs and bytecode caches.",
+				ElementType:         types.StringType,
+			},
+			"source_hash": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "SHA-256 content hash of the environment sources.",
+			},
+			"on_destroy": schema.StringAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "What happens to the deployed tasks when the resource is destroyed: `retain` leaves them in place, `deactivate` deactivates all triggers attached to them. Defaults to `retain`. The task service cannot deactivate tasks, so the deployed task versions stay registered and active in both cases.",
+				Default:             stringdefault.StaticString(taskEnvironmentRetain),
+			},
+			"flyte_cli": schema.SingleNestedAttribute{
+				Optional:            true,
+				MarkdownDescription: "Overrides the provider `flyte_cli` settings for this environment. Only used with `path`.",
+				Attributes: map[string]schema.Attribute{
+					"path": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Path of the flyte binary.",
+					},
+					"env": schema.MapAttribute{
+						Optional:            true,
+						MarkdownDescription: "Extra environment variables, merged with the provider ones.",
+						ElementType:         types.StringType,
+					},
+					"working_dir": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Directory the CLI runs in. Relative paths are resolved against it.",
+					},
+					"timeout": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Maximum duration of each CLI invocation, e.g. `15m`.",
+					},
+				},
+			},
 		},
 	}
 }
@@ -97,7 +180,7 @@ func (r *TaskEnvironmentResource) Configure(ctx context.Context, req resource.Co
 		return
 	}
 
-	_, ok := req.ProviderData.(*providerContext)
+	client, ok := req.ProviderData.(*providerContext)
 
 	if !ok {
 		resp.Diagnostics.AddError(
@@ -107,6 +190,104 @@ func (r *TaskEnvironmentResource) Configure(ctx context.Context, req resource.Co
 
 		return
 	}
+
+	r.conn = task.NewTaskServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *task.TaskServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.triggers = trigger.NewTriggerServiceClient(client.conn)
+	r.org = client.org
+	r.cli = client.flyteCLI
+}
+
+func (r *TaskEnvironmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TaskEnvironmentResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Unknown values are resolved at apply time
+	if data.Path.IsUnknown() || data.SpecFile.IsUnknown() {
+		return
+	}
+	if data.Path.IsNull() == data.SpecFile.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("spec_file"),
+			"Invalid Task Environment Source",
+			"Exactly one of path or spec_file must be set.",
+		)
+	}
+	if !data.OnDestroy.IsNull() && !data.OnDestroy.IsUnknown() &&
+		data.OnDestroy.ValueString() != taskEnvironmentRetain && data.OnDestroy.ValueString() != taskEnvironmentDeactivate {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("on_destroy"),
+			"Invalid on_destroy Value",
+			fmt.Sprintf("on_destroy must be %q or %q, got: %q", taskEnvironmentRetain, taskEnvironmentDeactivate, data.OnDestroy.ValueString()),
+		)
+	}
+	if _, err := (FlyteCLIConfig{}).withOverrides(data.FlyteCLI); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("flyte_cli").AtName("timeout"),
+			"Invalid flyte CLI Timeout",
+			err.Error(),
+		)
+	}
+}
+
+// details computes the name, version and tasks of the environment, either
+// from the spec bundle or from a dry run of the flyte CLI.
+func (r *TaskEnvironmentResource) details(ctx context.Context, data *TaskEnvironmentResourceModel) (*FlyteEnvironmentDetails, error) {
+	if !data.SpecFile.IsNull() {
+		reqs, err := loadTaskBundle(data.SpecFile.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		return taskBundleDetails(reqs)
+	}
+
+	cli, err := r.cli.withOverrides(data.FlyteCLI)
+	if err != nil {
+		return nil, err
+	}
+	return r.flyte.retrieveNameAndVersion(
+		ctx, cli,
+		data.Path.ValueString(),
+		data.Project.ValueString(),
+		data.Domain.ValueString(), data.Id.ValueString(),
+	)
+}
+
+// deployBundle registers every task of the spec bundle in the configured
+// project and domain. Tasks that already exist at the bundle version are
+// left untouched.
+func (r *TaskEnvironmentResource) deployBundle(ctx context.Context, data *TaskEnvironmentResourceModel) error {
+	reqs, err := loadTaskBundle(data.SpecFile.ValueString())
+	if err != nil {
+		return err
+	}
+	if _, err := taskBundleDetails(reqs); err != nil {
+		return err
+	}
+
+	for _, req := range reqs {
+		req.TaskId.Org = r.org
+		req.TaskId.Project = data.Project.ValueString()
+		req.TaskId.Domain = data.Domain.ValueString()
+
+		if _, err := r.conn.DeployTask(ctx, req); err != nil {
+			if status.Code(err) == codes.AlreadyExists {
+				continue
+			}
+			return fmt.Errorf("failed to deploy task %s: %w", req.GetTaskId().GetName(), err)
+		}
+	}
+	return nil
 }
 
 func (r *TaskEnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
@@ -128,16 +309,43 @@ func (r *TaskEnvironmentResource) ModifyPlan(ctx context.Context, req resource.M
 		}
 	}
 
-	details, err := r.flyte.retrieveNameAndVersion(
-		ctx,
-		plan.Path.ValueString(),
-		plan.Project.ValueString(),
-		plan.Domain.ValueString(), plan.Id.ValueString(),
-	)
+	// Sources that are not known yet are computed at apply time, see
+	// resolveUnknown
+	if plan.Path.IsUnknown() || plan.SpecFile.IsUnknown() || plan.SourceDir.IsUnknown() ||
+		plan.Include.IsUnknown() || plan.Exclude.IsUnknown() {
+		return
+	}
+
+	hash, err := r.sourceHash(ctx, &plan)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Task environment source hash failed",
+			fmt.Sprintf("Failed to hash the sources of %s: %s", r.source(&plan), err),
+		)
+		return
+	}
+	plan.SourceHash = types.StringValue(hash)
+
+	// The version calculation is expensive, so only redo it when the sources
+	// or the deployment target changed. A path without source_dir imports
+	// files the hash does not cover, so its version is always recalculated.
+	hashCoversSources := !plan.SourceDir.IsNull() || !plan.SpecFile.IsNull()
+	if hashCoversSources && !req.State.Raw.IsNull() && state.SourceHash.ValueString() == hash &&
+		!state.Version.IsNull() && state.Version.ValueString() != "" &&
+		plan.Id.Equal(state.Id) && plan.Project.Equal(state.Project) && plan.Domain.Equal(state.Domain) &&
+		plan.Path.Equal(state.Path) && plan.SpecFile.Equal(state.SpecFile) {
+		plan.Name = state.Name
+		plan.Version = state.Version
+		plan.Tasks = state.Tasks
+		resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+		return
+	}
+
+	details, err := r.details(ctx, &plan)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Task environment version calculation failed",
-			fmt.Sprintf("Failed to calculate version for %s: %s", plan.Path.ValueString(), err),
+			fmt.Sprintf("Failed to calculate version for %s: %s", r.source(&plan), err),
 		)
 		return
 	}
@@ -148,16 +356,108 @@ func (r *TaskEnvironmentResource) ModifyPlan(ctx context.Context, req resource.M
 
 	plan.Name = types.StringValue(details.Name)
 	plan.Version = types.StringValue(details.Version)
+	plan.Tasks = taskEnvironmentTasks(details.Tasks)
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
 
-	ts := details.Tasks
-	if ts == nil {
-		ts = []string{}
+// taskEnvironmentTasks returns the task names as a list, empty rather than
+// null when there are none.
+func taskEnvironmentTasks(tasks []string) types.List {
+	values := make([]attr.Value, 0, len(tasks))
+	for _, name := range tasks {
+		values = append(values, types.StringValue(name))
 	}
-	lv, diags := types.ListValueFrom(ctx, types.StringType, ts)
-	resp.Diagnostics.Append(diags...)
-	plan.Tasks = lv
+	return types.ListValueMust(types.StringType, values)
+}
 
-	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+// resolveUnknown computes the values that the plan left unknown because the
+// sources were not known yet. It runs after the deploy, once the whole
+// configuration is known.
+func (r *TaskEnvironmentResource) resolveUnknown(ctx context.Context, data *TaskEnvironmentResourceModel) error {
+	if data.SourceHash.IsUnknown() {
+		hash, err := r.sourceHash(ctx, data)
+		if err != nil {
+			return fmt.Errorf("failed to hash the sources: %w", err)
+		}
+		data.SourceHash = types.StringValue(hash)
+	}
+	if !data.Name.IsUnknown() && !data.Version.IsUnknown() && !data.Tasks.IsUnknown() {
+		return nil
+	}
+
+	details, err := r.details(ctx, data)
+	if err != nil {
+		return fmt.Errorf("failed to calculate the version: %w", err)
+	}
+	data.Name = types.StringValue(details.Name)
+	data.Version = types.StringValue(details.Version)
+	data.Tasks = taskEnvironmentTasks(details.Tasks)
+	return nil
+}
+
+// sourceHash computes the content hash of the environment sources: the
+// files of source_dir selected by the include and exclude globs, or the
+// single source file when no source_dir is set. A spec_file is always part
+// of the hash.
+func (r *TaskEnvironmentResource) sourceHash(ctx context.Context, data *TaskEnvironmentResourceModel) (string, error) {
+	resolve := func(p string) string { return p }
+	if data.SpecFile.IsNull() {
+		cli, err := r.cli.withOverrides(data.FlyteCLI)
+		if err != nil {
+			return "", err
+		}
+		resolve = cli.resolve
+	}
+
+	if data.SourceDir.IsNull() {
+		if !data.SpecFile.IsNull() {
+			return hashSourceFile(data.SpecFile.ValueString())
+		}
+		return hashSourceFile(resolve(data.Path.ValueString()))
+	}
+
+	var includes, excludes []string
+	if !data.Include.IsNull() {
+		if diags := data.Include.ElementsAs(ctx, &includes, false); diags.HasError() {
+			return "", fmt.Errorf("invalid include globs")
+		}
+	}
+	if !data.Exclude.IsNull() {
+		excludes = []string{}
+		if diags := data.Exclude.ElementsAs(ctx, &excludes, false); diags.HasError() {
+			return "", fmt.Errorf("invalid exclude globs")
+		}
+	}
+	hash, err := hashSourceDir(resolve(data.SourceDir.ValueString()), includes, excludes)
+	if err != nil || data.SpecFile.IsNull() {
+		return hash, err
+	}
+
+	// The bundle is what gets deployed, so a rebuilt bundle must change the
+	// hash even when the source tree is unchanged
+	specHash, err := hashSourceFile(data.SpecFile.ValueString())
+	if err != nil {
+		return "", err
+	}
+	return combineHashes(hash, specHash), nil
+}
+
+// deploy deploys the environment from its configured source.
+func (r *TaskEnvironmentResource) deploy(ctx context.Context, data *TaskEnvironmentResourceModel) error {
+	if !data.SpecFile.IsNull() {
+		return r.deployBundle(ctx, data)
+	}
+
+	cli, err := r.cli.withOverrides(data.FlyteCLI)
+	if err != nil {
+		return err
+	}
+	// Check if the python file exists
+	if _, err := os.Stat(cli.resolve(data.Path.ValueString())); os.IsNotExist(err) {
+		return fmt.Errorf("the path %s does not exist. Please check the path and try again", data.Path.ValueString())
+	}
+	return r.flyte.uploadNewVersion(ctx, cli, data.Path.ValueString(), data.Project.ValueString(), data.Domain.ValueString(), data.Id.ValueString())
 }
 
 func (r *TaskEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
@@ -170,11 +470,17 @@ func (r *TaskEnvironmentResource) Create(ctx context.Context, req resource.Creat
 		return
 	}
 
-	// Check if the python file exists
-	if _, err := os.Stat(data.Path.ValueString()); os.IsNotExist(err) {
+	if err := r.deploy(ctx, &data); err != nil {
 		resp.Diagnostics.AddError(
-			"Task environment path does not exist",
-			fmt.Sprintf("The path %s does not exist. Please check the path and try again.", data.Path.ValueString()),
+			"Task environment deployment failed",
+			fmt.Sprintf("Failed to deploy %s: %s", r.source(&data), err),
+		)
+		return
+	}
+	if err := r.resolveUnknown(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Task environment deployment failed",
+			fmt.Sprintf("Failed to read back %s: %s", r.source(&data), err),
 		)
 		return
 	}
@@ -192,24 +498,209 @@ func (r *TaskEnvironmentResource) Read(ctx context.Context, req resource.ReadReq
 		return
 	}
 
+	var tasks []string
+	resp.Diagnostics.Append(data.Tasks.ElementsAs(ctx, &tasks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var version string
+	var err error
+	if len(tasks) == 0 {
+		// Imported environments only know their name, so look their tasks up
+		tasks, version, err = r.discoverTasks(ctx, &data)
+	} else {
+		tasks, version, err = r.verifyTasks(ctx, &data, tasks)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read task environment %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	if len(tasks) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	drifted := (!data.Version.IsNull() && version != data.Version.ValueString()) ||
+		(!data.Tasks.IsNull() && len(tasks) != len(data.Tasks.Elements()))
+	if drifted {
+		tflog.Warn(ctx, "task environment drifted", map[string]interface{}{
+			"name":     data.Name.ValueString(),
+			"expected": data.Version.ValueString(),
+			"deployed": version,
+		})
+		// Force the next plan to recalculate the version
+		data.SourceHash = types.StringNull()
+	}
+	data.Version = types.StringValue(version)
+	lv, diags := types.ListValueFrom(ctx, types.StringType, tasks)
+	resp.Diagnostics.Append(diags...)
+	data.Tasks = lv
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
 
+// verifyTasks checks that every task of the environment is deployed at the
+// expected version. Tasks that no longer exist are dropped, and when a task
+// is missing at the expected version, the most recently deployed version is
+// returned instead so the drift shows up in the plan.
+func (r *TaskEnvironmentResource) verifyTasks(ctx context.Context, data *TaskEnvironmentResourceModel, tasks []string) ([]string, string, error) {
+	version := data.Version.ValueString()
+	found := make([]string, 0, len(tasks))
+	drifted := ""
+
+	for _, name := range tasks {
+		_, err := r.conn.GetTaskDetails(ctx, &task.GetTaskDetailsRequest{
+			TaskId: &task.TaskIdentifier{
+				Org:     r.org,
+				Project: data.Project.ValueString(),
+				Domain:  data.Domain.ValueString(),
+				Name:    name,
+				Version: version,
+			},
+		})
+		if err == nil {
+			found = append(found, name)
+			continue
+		}
+		if status.Code(err) != codes.NotFound {
+			return nil, "", err
+		}
+
+		latest, err := r.latestVersion(ctx, data, name)
+		if err != nil {
+			return nil, "", err
+		}
+		if latest != "" {
+			found = append(found, name)
+			if drifted == "" {
+				drifted = latest
+			}
+		}
+	}
+
+	if drifted != "" {
+		version = drifted
+	}
+	return found, version, nil
+}
+
+// latestVersion returns the most recently deployed version of a task, or an
+// empty string if the task does not exist.
+func (r *TaskEnvironmentResource) latestVersion(ctx context.Context, data *TaskEnvironmentResourceModel, name string) (string, error) {
+	var latest *task.ListVersionsResponse_VersionResponse
+	token := ""
+	for {
+		got, err := r.conn.ListVersions(ctx, &task.ListVersionsRequest{
+			Request: &flytecommon.ListRequest{Limit: 100, Token: token},
+			TaskName: &task.TaskName{
+				Org:     r.org,
+				Project: data.Project.ValueString(),
+				Domain:  data.Domain.ValueString(),
+				Name:    name,
+			},
+		})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return "", nil
+			}
+			return "", err
+		}
+		for _, v := range got.GetVersions() {
+			if latest == nil || v.GetDeployedAt().AsTime().After(latest.GetDeployedAt().AsTime()) {
+				latest = v
+			}
+		}
+		token = got.GetToken()
+		if token == "" {
+			break
+		}
+	}
+	return latest.GetVersion(), nil
+}
+
+// discoverTasks lists the tasks of the project and domain that belong to the
+// environment, returning their names and latest version.
+func (r *TaskEnvironmentResource) discoverTasks(ctx context.Context, data *TaskEnvironmentResourceModel) ([]string, string, error) {
+	var tasks []string
+	version := ""
+	token := ""
+	for {
+		got, err := r.conn.ListTasks(ctx, &task.ListTasksRequest{
+			Request: &flytecommon.ListRequest{Limit: 100, Token: token},
+			ScopeBy: &task.ListTasksRequest_ProjectId{
+				ProjectId: &flytecommon.ProjectIdentifier{
+					Organization: r.org,
+					Domain:       data.Domain.ValueString(),
+					Name:         data.Project.ValueString(),
+				},
+			},
+		})
+		if err != nil {
+			return nil, "", err
+		}
+		for _, t := range got.GetTasks() {
+			id := t.GetTaskId()
+			if t.GetMetadata().GetEnvironmentName() != data.Name.ValueString() &&
+				!strings.HasPrefix(id.GetName(), data.Name.ValueString()+".") {
+				continue
+			}
+			tasks = append(tasks, id.GetName())
+			if version == "" {
+				version = id.GetVersion()
+			}
+		}
+		token = got.GetToken()
+		if token == "" {
+			break
+		}
+	}
+	return tasks, version, nil
+}
+
+// taskEnvironmentSourcesEqual reports whether two models deploy the same
+// sources at the same version, so that only settings such as on_destroy or
+// flyte_cli differ between them.
+func taskEnvironmentSourcesEqual(a, b *TaskEnvironmentResourceModel) bool {
+	return a.Version.Equal(b.Version) &&
+		a.SourceHash.Equal(b.SourceHash) &&
+		a.Path.Equal(b.Path) &&
+		a.SpecFile.Equal(b.SpecFile) &&
+		a.SourceDir.Equal(b.SourceDir) &&
+		a.Include.Equal(b.Include) &&
+		a.Exclude.Equal(b.Exclude)
+}
+
 func (r *TaskEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	var data TaskEnvironmentResourceModel
+	var state TaskEnvironmentResourceModel
 
-	// Read Terraform plan data into the model
+	// Read Terraform plan and prior state data into the models
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
 
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	if err := r.flyte.uploadNewVersion(data.Path.ValueString(), data.Project.ValueString(), data.Domain.ValueString(), data.Id.ValueString()); err != nil {
+	// Settings that do not change the deployed tasks are only persisted
+	if taskEnvironmentSourcesEqual(&data, &state) {
+		resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+		return
+	}
+
+	if err := r.deploy(ctx, &data); err != nil {
 		resp.Diagnostics.AddError(
 			"Task environment update failed",
-			fmt.Sprintf("Failed to upload new version for %s: %s", data.Path.ValueString(), err),
+			fmt.Sprintf("Failed to upload new version for %s: %s", r.source(&data), err),
+		)
+		return
+	}
+	if err := r.resolveUnknown(ctx, &data); err != nil {
+		resp.Diagnostics.AddError(
+			"Task environment update failed",
+			fmt.Sprintf("Failed to read back %s: %s", r.source(&data), err),
 		)
 		return
 	}
@@ -227,8 +718,87 @@ func (r *TaskEnvironmentResource) Delete(ctx context.Context, req resource.Delet
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	// Deployed task versions are immutable, so by default they are left in place
+	if data.OnDestroy.ValueString() != taskEnvironmentDeactivate {
+		return
+	}
+
+	var tasks []string
+	resp.Diagnostics.Append(data.Tasks.ElementsAs(ctx, &tasks, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var names []*flytecommon.TriggerName
+	for _, name := range tasks {
+		token := ""
+		for {
+			got, err := r.triggers.ListTriggers(ctx, &trigger.ListTriggersRequest{
+				Request: &flytecommon.ListRequest{Limit: 100, Token: token},
+				ScopeBy: &trigger.ListTriggersRequest_TaskName{
+					TaskName: &task.TaskName{
+						Org:     r.org,
+						Project: data.Project.ValueString(),
+						Domain:  data.Domain.ValueString(),
+						Name:    name,
+					},
+				},
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list triggers of task %s, got error: %s", name, err))
+				return
+			}
+			for _, t := range got.GetTriggers() {
+				if t.GetActive() {
+					names = append(names, t.GetId().GetName())
+				}
+			}
+			token = got.GetToken()
+			if token == "" {
+				break
+			}
+		}
+	}
+	if len(names) == 0 {
+		return
+	}
+
+	if _, err := r.triggers.UpdateTriggers(ctx, &trigger.UpdateTriggersRequest{
+		Names:  names,
+		Active: false,
+	}); err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deactivate triggers of task environment %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
 }
 
+// ImportState accepts an identifier in the form
+// "{project}/{domain}/{name}/{id}", where name is the environment name and id
+// the object name the environment is deployed with. The deployed tasks only
+// record the environment name, so id cannot be looked up and must be given
+// as configured.
 func (r *TaskEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 || slices.Contains(parts, "") {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier in the form \"project/domain/name/id\", where id is the environment object name from the configuration, which the deployed tasks do not record, got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), parts[3])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("on_destroy"), taskEnvironmentRetain)...)
+}
+
+// source returns the configured file the environment is deployed from.
+func (r *TaskEnvironmentResource) source(data *TaskEnvironmentResourceModel) string {
+	if !data.SpecFile.IsNull() {
+		return data.SpecFile.ValueString()
+	}
+	return data.Path.ValueString()
 }
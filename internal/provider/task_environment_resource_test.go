@@ -0,0 +1,377 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// mockTaskClient implements the subset of task.TaskServiceClient used by
+// TaskEnvironmentResource. The embedded interface satisfies the rest.
+type mockTaskClient struct {
+	task.TaskServiceClient
+	// versions maps task names to their deployed versions, oldest first.
+	versions map[string][]string
+}
+
+func (m *mockTaskClient) DeployTask(ctx context.Context, in *task.DeployTaskRequest, opts ...grpc.CallOption) (*task.DeployTaskResponse, error) {
+	if m.versions == nil {
+		m.versions = map[string][]string{}
+	}
+	name := in.GetTaskId().GetName()
+	m.versions[name] = append(m.versions[name], in.GetTaskId().GetVersion())
+	return &task.DeployTaskResponse{}, nil
+}
+
+func (m *mockTaskClient) GetTaskDetails(ctx context.Context, in *task.GetTaskDetailsRequest, opts ...grpc.CallOption) (*task.GetTaskDetailsResponse, error) {
+	for _, v := range m.versions[in.GetTaskId().GetName()] {
+		if v == in.GetTaskId().GetVersion() {
+			return &task.GetTaskDetailsResponse{}, nil
+		}
+	}
+	return nil, status.Error(codes.NotFound, "task not found")
+}
+
+func (m *mockTaskClient) ListVersions(ctx context.Context, in *task.ListVersionsRequest, opts ...grpc.CallOption) (*task.ListVersionsResponse, error) {
+	resp := &task.ListVersionsResponse{}
+	base := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, v := range m.versions[in.GetTaskName().GetName()] {
+		resp.Versions = append(resp.Versions, &task.ListVersionsResponse_VersionResponse{
+			Version:    v,
+			DeployedAt: timestamppb.New(base.Add(time.Duration(i) * time.Hour)),
+		})
+	}
+	return resp, nil
+}
+
+func (m *mockTaskClient) ListTasks(ctx context.Context, in *task.ListTasksRequest, opts ...grpc.CallOption) (*task.ListTasksResponse, error) {
+	resp := &task.ListTasksResponse{}
+	for name, versions := range m.versions {
+		resp.Tasks = append(resp.Tasks, &task.Task{
+			TaskId: &task.TaskIdentifier{Name: name, Version: versions[len(versions)-1]},
+		})
+	}
+	return resp, nil
+}
+
+func taskEnvironmentModel(version string) *TaskEnvironmentResourceModel {
+	return &TaskEnvironmentResourceModel{
+		Name:    types.StringValue("hello_world"),
+		Project: types.StringValue("p"),
+		Domain:  types.StringValue("development"),
+		Version: types.StringValue(version),
+	}
+}
+
+func TestTaskEnvironmentResource_VerifyTasks(t *testing.T) {
+	r := &TaskEnvironmentResource{
+		org: "test-org",
+		conn: &mockTaskClient{versions: map[string][]string{
+			"hello_world.main": {"v1", "v2"},
+			"hello_world.fn":   {"v1", "v2"},
+		}},
+	}
+
+	tasks, version, err := r.verifyTasks(context.Background(), taskEnvironmentModel("v2"), []string{"hello_world.main", "hello_world.fn"})
+	if err != nil {
+		t.Fatalf("verifyTasks() returned error: %s", err)
+	}
+	if version != "v2" || len(tasks) != 2 {
+		t.Errorf("Expected both tasks at v2, got %v at %s", tasks, version)
+	}
+
+	// Deployed out of band at a version Terraform does not know about
+	tasks, version, err = r.verifyTasks(context.Background(), taskEnvironmentModel("v3"), []string{"hello_world.main", "hello_world.gone"})
+	if err != nil {
+		t.Fatalf("verifyTasks() returned error: %s", err)
+	}
+	if version != "v2" {
+		t.Errorf("Expected the latest deployed version v2 to surface as drift, got %s", version)
+	}
+	if len(tasks) != 1 || tasks[0] != "hello_world.main" {
+		t.Errorf("Expected the missing task to be dropped, got %v", tasks)
+	}
+}
+
+func TestTaskEnvironmentResource_DiscoverTasks(t *testing.T) {
+	r := &TaskEnvironmentResource{
+		org: "test-org",
+		conn: &mockTaskClient{versions: map[string][]string{
+			"hello_world.main": {"v1"},
+			"other_env.main":   {"v9"},
+		}},
+	}
+
+	tasks, version, err := r.discoverTasks(context.Background(), taskEnvironmentModel(""))
+	if err != nil {
+		t.Fatalf("discoverTasks() returned error: %s", err)
+	}
+	if len(tasks) != 1 || tasks[0] != "hello_world.main" || version != "v1" {
+		t.Errorf("Expected hello_world.main at v1, got %v at %s", tasks, version)
+	}
+}
+
+func TestTaskEnvironmentResource_ImportState(t *testing.T) {
+	ctx := context.Background()
+	r := &TaskEnvironmentResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+	emptyState := func() tfsdk.State {
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(schemaResp.Schema.Type().TerraformType(ctx), nil)}
+	}
+
+	resp := &resource.ImportStateResponse{State: emptyState()}
+	r.ImportState(ctx, resource.ImportStateRequest{ID: "p/development/hello_world/env"}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ImportState() errors: %v", resp.Diagnostics.Errors())
+	}
+	var data TaskEnvironmentResourceModel
+	resp.State.Get(ctx, &data)
+	if data.Project.ValueString() != "p" || data.Domain.ValueString() != "development" ||
+		data.Name.ValueString() != "hello_world" || data.Id.ValueString() != "env" {
+		t.Errorf("ImportState() = %+v", data)
+	}
+
+	for _, id := range []string{"p/development/hello_world", "p/development/hello_world/", "p//hello_world/env"} {
+		resp := &resource.ImportStateResponse{State: emptyState()}
+		r.ImportState(ctx, resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected ImportState(%q) to fail", id)
+		}
+	}
+}
+
+func TestTaskEnvironmentResource_ModifyPlan_SkipsUnchangedSources(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.py"), []byte("import helpers\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write source: %s", err)
+	}
+	// The CLI prints a new version on every call, so a recalculation shows up
+	calls := filepath.Join(dir, "calls")
+	script := filepath.Join(dir, "flyte")
+	if err := os.WriteFile(script, []byte(`#!/bin/sh
+[ "$3" = --help ] && exit 0
+echo x >> `+calls+`
+echo "{\"environments\": [{\"name\": \"env\"}], \"entities\": [{\"type\": \"task\", \"name\": \"env.main\", \"version\": \"v$(wc -l < `+calls+` | tr -d ' ')\"}]}"
+`), 0o755); err != nil {
+		t.Fatalf("Failed to write script: %s", err)
+	}
+
+	r := &TaskEnvironmentResource{cli: FlyteCLIConfig{Path: script, WorkingDir: dir}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	for sourceDir, recalculated := range map[string]bool{"": true, ".": false} {
+		data := TaskEnvironmentResourceModel{
+			Id:        types.StringValue("env"),
+			Name:      types.StringValue("env"),
+			Path:      types.StringValue("hello.py"),
+			SpecFile:  types.StringNull(),
+			Project:   types.StringValue("p"),
+			Domain:    types.StringValue("development"),
+			Version:   types.StringValue("v0"),
+			Tasks:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("env.main")}),
+			SourceDir: types.StringNull(),
+			Include:   types.ListNull(types.StringType),
+			Exclude:   types.ListNull(types.StringType),
+			OnDestroy: types.StringValue(taskEnvironmentRetain),
+		}
+		if sourceDir != "" {
+			data.SourceDir = types.StringValue(sourceDir)
+		}
+		hash, err := r.sourceHash(ctx, &data)
+		if err != nil {
+			t.Fatalf("sourceHash() returned error: %s", err)
+		}
+		data.SourceHash = types.StringValue(hash)
+
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		state.Set(ctx, &data)
+		plan.Set(ctx, &data)
+		resp := &resource.ModifyPlanResponse{Plan: plan}
+		r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("ModifyPlan() with source_dir %q errors: %v", sourceDir, resp.Diagnostics.Errors())
+		}
+
+		var planned TaskEnvironmentResourceModel
+		resp.Plan.Get(ctx, &planned)
+		if got := planned.Version.ValueString() != "v0"; got != recalculated {
+			t.Errorf("With source_dir %q, expected recalculation %t, got version %s", sourceDir, recalculated, planned.Version.ValueString())
+		}
+	}
+}
+
+func TestTaskEnvironmentResource_Update_SkipsDeployForSettings(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	// Any deploy fails, since neither the CLI nor the source exist
+	r := &TaskEnvironmentResource{cli: FlyteCLIConfig{Path: filepath.Join(dir, "flyte"), WorkingDir: dir}}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	prior := TaskEnvironmentResourceModel{
+		Id:         types.StringValue("env"),
+		Name:       types.StringValue("env"),
+		Path:       types.StringValue("hello.py"),
+		SpecFile:   types.StringNull(),
+		Project:    types.StringValue("p"),
+		Domain:     types.StringValue("development"),
+		Version:    types.StringValue("v1"),
+		Tasks:      types.ListValueMust(types.StringType, []attr.Value{types.StringValue("env.main")}),
+		SourceDir:  types.StringNull(),
+		Include:    types.ListNull(types.StringType),
+		Exclude:    types.ListNull(types.StringType),
+		SourceHash: types.StringValue("abc"),
+		OnDestroy:  types.StringValue(taskEnvironmentRetain),
+	}
+
+	update := func(data TaskEnvironmentResourceModel) *resource.UpdateResponse {
+		state := tfsdk.State{Schema: schemaResp.Schema}
+		plan := tfsdk.Plan{Schema: schemaResp.Schema}
+		state.Set(ctx, &prior)
+		plan.Set(ctx, &data)
+		resp := &resource.UpdateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+		r.Update(ctx, resource.UpdateRequest{Plan: plan, State: state}, resp)
+		return resp
+	}
+
+	settings := prior
+	settings.OnDestroy = types.StringValue(taskEnvironmentDeactivate)
+	resp := update(settings)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Update() of on_destroy errors: %v", resp.Diagnostics.Errors())
+	}
+	var got TaskEnvironmentResourceModel
+	resp.State.Get(ctx, &got)
+	if got.OnDestroy.ValueString() != taskEnvironmentDeactivate {
+		t.Errorf("Expected on_destroy to be persisted, got %s", got.OnDestroy.ValueString())
+	}
+
+	version := prior
+	version.Version = types.StringValue("v2")
+	if resp := update(version); !resp.Diagnostics.HasError() {
+		t.Error("Expected Update() of the version to deploy")
+	}
+}
+
+func TestTaskEnvironmentResource_Create_ResolvesUnknownValues(t *testing.T) {
+	ctx := context.Background()
+	bundle := writeTaskBundle(t, "bundle.json", []byte(`[
+		{"taskId": {"name": "env.main", "version": "v1"}, "spec": {"environment": {"name": "env"}}}
+	]`))
+
+	client := &mockTaskClient{}
+	r := &TaskEnvironmentResource{conn: client, org: "test-org"}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	// The spec file was unknown at plan time, so nothing was computed
+	data := TaskEnvironmentResourceModel{
+		Id:         types.StringValue("env"),
+		Name:       types.StringUnknown(),
+		Path:       types.StringNull(),
+		SpecFile:   types.StringValue(bundle),
+		Project:    types.StringValue("p"),
+		Domain:     types.StringValue("development"),
+		Version:    types.StringUnknown(),
+		Tasks:      types.ListUnknown(types.StringType),
+		SourceDir:  types.StringNull(),
+		Include:    types.ListNull(types.StringType),
+		Exclude:    types.ListNull(types.StringType),
+		SourceHash: types.StringUnknown(),
+		OnDestroy:  types.StringValue(taskEnvironmentRetain),
+	}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	plan.Set(ctx, &data)
+	resp := &resource.CreateResponse{State: tfsdk.State{Schema: schemaResp.Schema}}
+	r.Create(ctx, resource.CreateRequest{Plan: plan}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("Create() errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var got TaskEnvironmentResourceModel
+	resp.State.Get(ctx, &got)
+	if got.Name.ValueString() != "env" || got.Version.ValueString() != "v1" || len(got.Tasks.Elements()) != 1 {
+		t.Errorf("Expected env at v1 with one task, got %s at %s with %s", got.Name, got.Version, got.Tasks)
+	}
+	if got.SourceHash.IsUnknown() || got.SourceHash.IsNull() {
+		t.Errorf("Expected the source hash to be computed, got %s", got.SourceHash)
+	}
+	if len(client.versions["env.main"]) != 1 {
+		t.Errorf("Expected env.main to be deployed once, got %v", client.versions)
+	}
+}
+
+func TestTaskEnvironmentResource_ModifyPlan_RebuiltBundle(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hello.py"), []byte("print('hello')\n"), 0o644); err != nil {
+		t.Fatalf("Failed to write source: %s", err)
+	}
+	bundle := writeTaskBundle(t, "bundle.json", []byte(`{"taskId": {"name": "env.main", "version": "v1"}}`))
+
+	r := &TaskEnvironmentResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(ctx, resource.SchemaRequest{}, schemaResp)
+
+	data := TaskEnvironmentResourceModel{
+		Id:        types.StringValue("env"),
+		Name:      types.StringValue("env"),
+		Path:      types.StringNull(),
+		SpecFile:  types.StringValue(bundle),
+		Project:   types.StringValue("p"),
+		Domain:    types.StringValue("development"),
+		Version:   types.StringValue("v1"),
+		Tasks:     types.ListValueMust(types.StringType, []attr.Value{types.StringValue("env.main")}),
+		SourceDir: types.StringValue(dir),
+		Include:   types.ListNull(types.StringType),
+		Exclude:   types.ListNull(types.StringType),
+		OnDestroy: types.StringValue(taskEnvironmentRetain),
+	}
+	hash, err := r.sourceHash(ctx, &data)
+	if err != nil {
+		t.Fatalf("sourceHash() returned error: %s", err)
+	}
+	data.SourceHash = types.StringValue(hash)
+
+	// The bundle is rebuilt while the source tree stays the same
+	if err := os.WriteFile(bundle, []byte(`{"taskId": {"name": "env.main", "version": "v2"}}`), 0o600); err != nil {
+		t.Fatalf("Failed to rewrite bundle: %s", err)
+	}
+
+	state := tfsdk.State{Schema: schemaResp.Schema}
+	plan := tfsdk.Plan{Schema: schemaResp.Schema}
+	state.Set(ctx, &data)
+	plan.Set(ctx, &data)
+	resp := &resource.ModifyPlanResponse{Plan: plan}
+	r.ModifyPlan(ctx, resource.ModifyPlanRequest{Plan: plan, State: state}, resp)
+	if resp.Diagnostics.HasError() {
+		t.Fatalf("ModifyPlan() errors: %v", resp.Diagnostics.Errors())
+	}
+
+	var planned TaskEnvironmentResourceModel
+	resp.Plan.Get(ctx, &planned)
+	if planned.SourceHash.Equal(data.SourceHash) || planned.Version.ValueString() != "v2" {
+		t.Errorf("Expected the rebuilt bundle to plan version v2 with a new hash, got %s with %s", planned.Version, planned.SourceHash)
+	}
+	if taskEnvironmentSourcesEqual(&planned, &data) {
+		t.Error("Expected the rebuilt bundle to be deployed on update")
+	}
+}
@@ -0,0 +1,671 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	flytecommon "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/common"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/core"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/trigger"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ resource.Resource = &TriggerResource{}
+var _ resource.ResourceWithImportState = &TriggerResource{}
+var _ resource.ResourceWithValidateConfig = &TriggerResource{}
+
+func NewTriggerResource() resource.Resource {
+	return &TriggerResource{}
+}
+
+// TriggerResource manages a task trigger through the flyteidl2
+// TriggerService. Every deploy creates a new trigger revision, while
+// activating or deactivating goes through UpdateTriggers so the schedule is
+// toggled without redeploying it.
+type TriggerResource struct {
+	conn trigger.TriggerServiceClient
+	org  string
+}
+
+// TriggerResourceModel describes the resource data model.
+type TriggerResourceModel struct {
+	Id                  types.String           `tfsdk:"id"`
+	Name                types.String           `tfsdk:"name"`
+	Project             types.String           `tfsdk:"project"`
+	Domain              types.String           `tfsdk:"domain"`
+	TaskName            types.String           `tfsdk:"task_name"`
+	TaskVersion         types.String           `tfsdk:"task_version"`
+	Description         types.String           `tfsdk:"description"`
+	Active              types.Bool             `tfsdk:"active"`
+	KickoffTimeInputArg types.String           `tfsdk:"kickoff_time_input_arg"`
+	Cron                *TriggerCronModel      `tfsdk:"cron"`
+	FixedRate           *TriggerFixedRateModel `tfsdk:"fixed_rate"`
+	Labels              types.Map              `tfsdk:"labels"`
+	Annotations         types.Map              `tfsdk:"annotations"`
+	Envs                types.Map              `tfsdk:"envs"`
+	Interruptible       types.Bool             `tfsdk:"interruptible"`
+	OverwriteCache      types.Bool             `tfsdk:"overwrite_cache"`
+	Cluster             types.String           `tfsdk:"cluster"`
+	Revision            types.Int64            `tfsdk:"revision"`
+	DeployedAt          types.String           `tfsdk:"deployed_at"`
+	UpdatedAt           types.String           `tfsdk:"updated_at"`
+}
+
+type TriggerCronModel struct {
+	Expression types.String `tfsdk:"expression"`
+	Timezone   types.String `tfsdk:"timezone"`
+}
+
+type TriggerFixedRateModel struct {
+	Value     types.Int64  `tfsdk:"value"`
+	Unit      types.String `tfsdk:"unit"`
+	StartTime types.String `tfsdk:"start_time"`
+}
+
+func (r *TriggerResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger"
+}
+
+func (r *TriggerResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Trigger resource. A trigger launches runs of a task on a cron or fixed-rate schedule.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Trigger identifier, in the form `{project}/{domain}/{task_name}/{name}`.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Trigger name, unique per task.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"project": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Project of the triggered task.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Domain of the triggered task.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"task_name": schema.StringAttribute{
+				Required:            true,
+				MarkdownDescription: "Name of the triggered task (e.g. `my_env.main`).",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"task_version": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Task version to run. If unset, the latest version of the task is run.",
+			},
+			"description": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Trigger description",
+			},
+			"active": schema.BoolAttribute{
+				Optional:            true,
+				Computed:            true,
+				MarkdownDescription: "Whether the trigger launches runs. Toggling this activates or deactivates the trigger in place. Defaults to `true`.",
+				Default:             booldefault.StaticBool(true),
+			},
+			"kickoff_time_input_arg": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Name of the task input that receives the scheduled kickoff time.",
+			},
+			"labels": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Labels applied to the triggered runs.",
+			},
+			"annotations": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Annotations applied to the triggered runs.",
+			},
+			"envs": schema.MapAttribute{
+				ElementType:         types.StringType,
+				Optional:            true,
+				MarkdownDescription: "Environment variables set on the triggered runs.",
+			},
+			"interruptible": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Overrides whether the triggered runs are interruptible. If unset, the task setting is used.",
+			},
+			"overwrite_cache": schema.BoolAttribute{
+				Optional:            true,
+				MarkdownDescription: "Whether the triggered runs ignore cached outputs and overwrite them.",
+			},
+			"cluster": schema.StringAttribute{
+				Optional:            true,
+				MarkdownDescription: "Cluster the triggered runs are sent to.",
+			},
+			"revision": schema.Int64Attribute{
+				Computed:            true,
+				MarkdownDescription: "Latest revision of the trigger.",
+			},
+			"deployed_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the trigger was first deployed, in RFC 3339 format.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"updated_at": schema.StringAttribute{
+				Computed:            true,
+				MarkdownDescription: "Time the trigger was last updated, in RFC 3339 format.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"cron": schema.SingleNestedBlock{
+				MarkdownDescription: "Cron schedule. Conflicts with `fixed_rate`.",
+				Attributes: map[string]schema.Attribute{
+					"expression": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Cron expression (e.g. `0 * * * *`).",
+					},
+					"timezone": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "IANA timezone the expression is evaluated in. Defaults to UTC.",
+					},
+				},
+			},
+			"fixed_rate": schema.SingleNestedBlock{
+				MarkdownDescription: "Fixed-rate schedule. Conflicts with `cron`.",
+				Attributes: map[string]schema.Attribute{
+					"value": schema.Int64Attribute{
+						Optional:            true,
+						MarkdownDescription: "Number of units between runs.",
+					},
+					"unit": schema.StringAttribute{
+						Optional:            true,
+						MarkdownDescription: "Rate unit, one of `FIXED_RATE_UNIT_MINUTE`, `FIXED_RATE_UNIT_HOUR` or `FIXED_RATE_UNIT_DAY`.",
+						Validators: []validator.String{
+							stringOneOf(
+								task.FixedRateUnit_FIXED_RATE_UNIT_MINUTE.String(),
+								task.FixedRateUnit_FIXED_RATE_UNIT_HOUR.String(),
+								task.FixedRateUnit_FIXED_RATE_UNIT_DAY.String(),
+							),
+						},
+					},
+					"start_time": schema.StringAttribute{
+						Optional:            true,
+						Computed:            true,
+						MarkdownDescription: "Time of the first run, in RFC 3339 format. Defaults to the deploy time.",
+						PlanModifiers: []planmodifier.String{
+							stringplanmodifier.UseStateForUnknown(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r *TriggerResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.conn = trigger.NewTriggerServiceClient(client.conn)
+	if r.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *trigger.TriggerServiceClient, got: %T. Please report this issue to the provider developers.", r.conn),
+		)
+		return
+	}
+	r.org = client.org
+}
+
+func (r *TriggerResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data TriggerResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Cron != nil && data.FixedRate != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fixed_rate"),
+			"Conflicting Trigger Schedules",
+			"Only one of cron or fixed_rate can be set.",
+		)
+		return
+	}
+	if data.Cron != nil && data.Cron.Expression.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("cron").AtName("expression"),
+			"Missing Cron Expression",
+			"The cron block requires an expression.",
+		)
+	}
+	if data.FixedRate != nil && (data.FixedRate.Value.IsNull() || data.FixedRate.Unit.IsNull()) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("fixed_rate"),
+			"Incomplete Fixed Rate",
+			"The fixed_rate block requires both value and unit.",
+		)
+	}
+	if !data.KickoffTimeInputArg.IsNull() && data.Cron == nil && data.FixedRate == nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kickoff_time_input_arg"),
+			"Missing Trigger Schedule",
+			"kickoff_time_input_arg requires a cron or fixed_rate schedule.",
+		)
+	}
+}
+
+func (r *TriggerResource) triggerName(data *TriggerResourceModel) *flytecommon.TriggerName {
+	return &flytecommon.TriggerName{
+		Org:      r.org,
+		Project:  data.Project.ValueString(),
+		Domain:   data.Domain.ValueString(),
+		TaskName: data.TaskName.ValueString(),
+		Name:     data.Name.ValueString(),
+	}
+}
+
+// automationSpec builds the trigger schedule from the cron or fixed_rate block.
+func (r *TriggerResource) automationSpec(data *TriggerResourceModel) (*task.TriggerAutomationSpec, error) {
+	schedule := &task.Schedule{
+		KickoffTimeInputArg: data.KickoffTimeInputArg.ValueString(),
+	}
+
+	switch {
+	case data.Cron != nil:
+		schedule.Expression = &task.Schedule_Cron{
+			Cron: &task.Cron{
+				Expression: data.Cron.Expression.ValueString(),
+				Timezone:   data.Cron.Timezone.ValueString(),
+			},
+		}
+	case data.FixedRate != nil:
+		unit, ok := task.FixedRateUnit_value[data.FixedRate.Unit.ValueString()]
+		if !ok || unit == int32(task.FixedRateUnit_FIXED_RATE_UNIT_UNSPECIFIED) {
+			return nil, fmt.Errorf("invalid fixed rate unit %q, must be one of FIXED_RATE_UNIT_MINUTE, FIXED_RATE_UNIT_HOUR or FIXED_RATE_UNIT_DAY", data.FixedRate.Unit.ValueString())
+		}
+		rate := &task.FixedRate{
+			Value: uint32(data.FixedRate.Value.ValueInt64()),
+			Unit:  task.FixedRateUnit(unit),
+		}
+		if !data.FixedRate.StartTime.IsNull() && !data.FixedRate.StartTime.IsUnknown() {
+			startTime, err := time.Parse(time.RFC3339, data.FixedRate.StartTime.ValueString())
+			if err != nil {
+				return nil, fmt.Errorf("invalid fixed rate start_time %q: %w", data.FixedRate.StartTime.ValueString(), err)
+			}
+			rate.StartTime = timestamppb.New(startTime)
+		}
+		schedule.Expression = &task.Schedule_Rate{
+			Rate: rate,
+		}
+	default:
+		return &task.TriggerAutomationSpec{
+			Type: task.TriggerAutomationSpecType_TYPE_NONE,
+		}, nil
+	}
+
+	return &task.TriggerAutomationSpec{
+		Type: task.TriggerAutomationSpecType_TYPE_SCHEDULE,
+		Automation: &task.TriggerAutomationSpec_Schedule{
+			Schedule: schedule,
+		},
+	}, nil
+}
+
+// spec builds the trigger spec, including the run overrides.
+func (r *TriggerResource) spec(data *TriggerResourceModel) *trigger.TriggerSpec {
+	runSpec := &task.RunSpec{
+		OverwriteCache: data.OverwriteCache.ValueBool(),
+		Cluster:        data.Cluster.ValueString(),
+	}
+	if !data.Labels.IsNull() {
+		runSpec.Labels = &task.Labels{Values: convertMapToStrings(data.Labels)}
+	}
+	if !data.Annotations.IsNull() {
+		runSpec.Annotations = &task.Annotations{Values: convertMapToStrings(data.Annotations)}
+	}
+	if !data.Envs.IsNull() {
+		envs := &task.Envs{}
+		for key, value := range convertMapToStrings(data.Envs) {
+			envs.Values = append(envs.Values, &core.KeyValuePair{Key: key, Value: value})
+		}
+		runSpec.Envs = envs
+	}
+	if !data.Interruptible.IsNull() {
+		runSpec.Interruptible = wrapperspb.Bool(data.Interruptible.ValueBool())
+	}
+
+	return &trigger.TriggerSpec{
+		RunSpec:     runSpec,
+		Active:      data.Active.ValueBool(),
+		TaskVersion: data.TaskVersion.ValueString(),
+		Description: data.Description.ValueString(),
+	}
+}
+
+// refresh copies the latest trigger revision into the model.
+func (r *TriggerResource) refresh(data *TriggerResourceModel, details *trigger.TriggerDetails) {
+	name := details.GetId().GetName()
+	data.Id = types.StringValue(triggerId(name))
+	data.Name = types.StringValue(name.GetName())
+	data.Project = types.StringValue(name.GetProject())
+	data.Domain = types.StringValue(name.GetDomain())
+	data.TaskName = types.StringValue(name.GetTaskName())
+	data.Revision = types.Int64Value(int64(details.GetId().GetRevision()))
+	data.DeployedAt = convertTimestampToString(details.GetStatus().GetDeployedAt())
+	data.UpdatedAt = convertTimestampToString(details.GetStatus().GetUpdatedAt())
+
+	spec := details.GetSpec()
+	data.Active = types.BoolValue(spec.GetActive())
+	data.TaskVersion = optionalString(spec.GetTaskVersion())
+	data.Description = optionalString(spec.GetDescription())
+
+	runSpec := spec.GetRunSpec()
+	data.Labels = convertStringsToMap(runSpec.GetLabels().GetValues())
+	data.Annotations = convertStringsToMap(runSpec.GetAnnotations().GetValues())
+	envs := make(map[string]string, len(runSpec.GetEnvs().GetValues()))
+	for _, kv := range runSpec.GetEnvs().GetValues() {
+		envs[kv.GetKey()] = kv.GetValue()
+	}
+	data.Envs = convertStringsToMap(envs)
+	if runSpec.GetInterruptible() != nil {
+		data.Interruptible = types.BoolValue(runSpec.GetInterruptible().GetValue())
+	} else {
+		data.Interruptible = types.BoolNull()
+	}
+	// Only surface overwrite_cache when it was configured or is enabled remotely
+	if runSpec.GetOverwriteCache() || !data.OverwriteCache.IsNull() {
+		data.OverwriteCache = types.BoolValue(runSpec.GetOverwriteCache())
+	}
+	data.Cluster = optionalString(runSpec.GetCluster())
+
+	schedule := details.GetAutomationSpec().GetSchedule()
+	data.KickoffTimeInputArg = optionalString(schedule.GetKickoffTimeInputArg())
+	priorStartTime := types.StringNull()
+	if data.FixedRate != nil {
+		priorStartTime = data.FixedRate.StartTime
+	}
+	data.Cron = nil
+	data.FixedRate = nil
+	switch {
+	case schedule.GetCron() != nil:
+		data.Cron = &TriggerCronModel{
+			Expression: types.StringValue(schedule.GetCron().GetExpression()),
+			Timezone:   optionalString(schedule.GetCron().GetTimezone()),
+		}
+	case schedule.GetCronExpression() != "":
+		data.Cron = &TriggerCronModel{
+			Expression: types.StringValue(schedule.GetCronExpression()),
+			Timezone:   types.StringNull(),
+		}
+	case schedule.GetRate() != nil:
+		rate := schedule.GetRate()
+		data.FixedRate = &TriggerFixedRateModel{
+			Value:     types.Int64Value(int64(rate.GetValue())),
+			Unit:      types.StringValue(rate.GetUnit().String()),
+			StartTime: triggerStartTime(priorStartTime, rate.GetStartTime()),
+		}
+	}
+}
+
+// triggerStartTime returns the start time read from the server, keeping the
+// prior value when it is the same instant written with a different offset.
+func triggerStartTime(prior types.String, startTime *timestamppb.Timestamp) types.String {
+	if startTime != nil && !prior.IsNull() && !prior.IsUnknown() {
+		if t, err := time.Parse(time.RFC3339, prior.ValueString()); err == nil && t.Equal(startTime.AsTime()) {
+			return prior
+		}
+	}
+	return convertTimestampToString(startTime)
+}
+
+func (r *TriggerResource) deploy(ctx context.Context, data *TriggerResourceModel, revision uint64) (*trigger.TriggerDetails, error) {
+	automationSpec, err := r.automationSpec(data)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.conn.DeployTrigger(ctx, &trigger.DeployTriggerRequest{
+		Name:           r.triggerName(data),
+		Revision:       revision,
+		Spec:           r.spec(data),
+		AutomationSpec: automationSpec,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.GetTrigger(), nil
+}
+
+func (r *TriggerResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data TriggerResourceModel
+
+	// Read Terraform plan data into the model
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// A new trigger starts at revision 1; the backend ignores it for new triggers
+	details, err := r.deploy(ctx, &data, 1)
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deploy trigger %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refreshComputed(&data, details)
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// refreshComputed copies only the computed attributes of a trigger into the
+// model, leaving the planned configuration untouched.
+func (r *TriggerResource) refreshComputed(data *TriggerResourceModel, details *trigger.TriggerDetails) {
+	data.Id = types.StringValue(triggerId(r.triggerName(data)))
+	data.Revision = types.Int64Value(int64(details.GetId().GetRevision()))
+	data.DeployedAt = convertTimestampToString(details.GetStatus().GetDeployedAt())
+	data.UpdatedAt = convertTimestampToString(details.GetStatus().GetUpdatedAt())
+	// An unset start time defaults to the deploy time on the server
+	if data.FixedRate != nil && data.FixedRate.StartTime.IsUnknown() {
+		data.FixedRate.StartTime = convertTimestampToString(details.GetAutomationSpec().GetSchedule().GetRate().GetStartTime())
+	}
+}
+
+func (r *TriggerResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data TriggerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	got, err := r.conn.GetTriggerDetails(ctx, &trigger.GetTriggerDetailsRequest{
+		Name: r.triggerName(&data),
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			resp.State.RemoveResource(ctx)
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trigger %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	if got.GetTrigger().GetStatus().GetDeletedAt() != nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	r.refresh(&data, got.GetTrigger())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TriggerResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data TriggerResourceModel
+	var state TriggerResourceModel
+
+	// Read Terraform plan and prior state data into the models
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := r.triggerName(&data)
+
+	// Toggling the active flag alone does not need a new deploy
+	activeOnly := state
+	activeOnly.Active = data.Active
+	activeOnly.Revision = data.Revision
+	activeOnly.UpdatedAt = data.UpdatedAt
+	if triggerConfigEqual(&activeOnly, &data) {
+		if _, err := r.conn.UpdateTriggers(ctx, &trigger.UpdateTriggersRequest{
+			Names:  []*flytecommon.TriggerName{name},
+			Active: data.Active.ValueBool(),
+		}); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to update trigger %s, got error: %s", data.Name.ValueString(), err))
+			return
+		}
+	} else {
+		// Deploys are optimistically locked on the latest revision
+		latest, err := r.conn.GetTriggerDetails(ctx, &trigger.GetTriggerDetailsRequest{Name: name})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trigger %s, got error: %s", data.Name.ValueString(), err))
+			return
+		}
+		if _, err := r.deploy(ctx, &data, latest.GetTrigger().GetId().GetRevision()); err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to deploy trigger %s, got error: %s", data.Name.ValueString(), err))
+			return
+		}
+	}
+
+	got, err := r.conn.GetTriggerDetails(ctx, &trigger.GetTriggerDetailsRequest{Name: name})
+	if err != nil {
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trigger %s after update, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+	r.refreshComputed(&data, got.GetTrigger())
+
+	// Save updated data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *TriggerResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data TriggerResourceModel
+
+	// Read Terraform prior state data into the model
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, err := r.conn.DeleteTriggers(ctx, &trigger.DeleteTriggersRequest{
+		Names: []*flytecommon.TriggerName{r.triggerName(&data)},
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return
+		}
+		resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to delete trigger %s, got error: %s", data.Name.ValueString(), err))
+		return
+	}
+}
+
+// ImportState accepts an identifier in the form "{project}/{domain}/{task_name}/{name}".
+func (r *TriggerResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	parts := strings.Split(req.ID, "/")
+	if len(parts) != 4 || parts[0] == "" || parts[1] == "" || parts[2] == "" || parts[3] == "" {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier in the form \"project/domain/task_name/name\", got: %q", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), req.ID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project"), parts[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("domain"), parts[1])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("task_name"), parts[2])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), parts[3])...)
+}
+
+func triggerId(name *flytecommon.TriggerName) string {
+	return strings.Join([]string{name.GetProject(), name.GetDomain(), name.GetTaskName(), name.GetName()}, "/")
+}
+
+// triggerConfigEqual reports whether two trigger models describe the same
+// deployable configuration.
+func triggerConfigEqual(a, b *TriggerResourceModel) bool {
+	if !a.TaskVersion.Equal(b.TaskVersion) ||
+		!a.Description.Equal(b.Description) ||
+		!a.Active.Equal(b.Active) ||
+		!a.KickoffTimeInputArg.Equal(b.KickoffTimeInputArg) ||
+		!a.Labels.Equal(b.Labels) ||
+		!a.Annotations.Equal(b.Annotations) ||
+		!a.Envs.Equal(b.Envs) ||
+		!a.Interruptible.Equal(b.Interruptible) ||
+		!a.OverwriteCache.Equal(b.OverwriteCache) ||
+		!a.Cluster.Equal(b.Cluster) {
+		return false
+	}
+	if (a.Cron == nil) != (b.Cron == nil) || (a.FixedRate == nil) != (b.FixedRate == nil) {
+		return false
+	}
+	if a.Cron != nil && (!a.Cron.Expression.Equal(b.Cron.Expression) || !a.Cron.Timezone.Equal(b.Cron.Timezone)) {
+		return false
+	}
+	if a.FixedRate != nil && (!a.FixedRate.Value.Equal(b.FixedRate.Value) ||
+		!a.FixedRate.Unit.Equal(b.FixedRate.Unit) ||
+		!a.FixedRate.StartTime.Equal(b.FixedRate.StartTime)) {
+		return false
+	}
+	return true
+}
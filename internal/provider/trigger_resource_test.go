@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/trigger"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestTriggerResource_Metadata(t *testing.T) {
+	r := NewTriggerResource()
+	resp := &resource.MetadataResponse{}
+	r.Metadata(context.Background(), resource.MetadataRequest{ProviderTypeName: "unionai"}, resp)
+
+	if resp.TypeName != "unionai_trigger" {
+		t.Errorf("Expected type name 'unionai_trigger', got '%s'", resp.TypeName)
+	}
+}
+
+func TestTriggerResource_AutomationSpec(t *testing.T) {
+	r := &TriggerResource{org: "test-org"}
+
+	spec, err := r.automationSpec(&TriggerResourceModel{
+		KickoffTimeInputArg: types.StringValue("kickoff_time"),
+		Cron: &TriggerCronModel{
+			Expression: types.StringValue("0 2 * * *"),
+			Timezone:   types.StringValue("Europe/Berlin"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("automationSpec() returned error: %s", err)
+	}
+	if spec.GetType() != task.TriggerAutomationSpecType_TYPE_SCHEDULE {
+		t.Errorf("Expected schedule type, got %s", spec.GetType())
+	}
+	if got := spec.GetSchedule().GetCron().GetExpression(); got != "0 2 * * *" {
+		t.Errorf("Expected cron expression '0 2 * * *', got '%s'", got)
+	}
+	if got := spec.GetSchedule().GetKickoffTimeInputArg(); got != "kickoff_time" {
+		t.Errorf("Expected kickoff input 'kickoff_time', got '%s'", got)
+	}
+
+	spec, err = r.automationSpec(&TriggerResourceModel{
+		FixedRate: &TriggerFixedRateModel{
+			Value:     types.Int64Value(15),
+			Unit:      types.StringValue("FIXED_RATE_UNIT_MINUTE"),
+			StartTime: types.StringValue("2025-01-01T00:00:00Z"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("automationSpec() returned error: %s", err)
+	}
+	rate := spec.GetSchedule().GetRate()
+	if rate.GetValue() != 15 || rate.GetUnit() != task.FixedRateUnit_FIXED_RATE_UNIT_MINUTE {
+		t.Errorf("Expected a 15 minute rate, got %d %s", rate.GetValue(), rate.GetUnit())
+	}
+	if rate.GetStartTime().AsTime().Year() != 2025 {
+		t.Errorf("Expected start time in 2025, got %s", rate.GetStartTime().AsTime())
+	}
+
+	spec, err = r.automationSpec(&TriggerResourceModel{})
+	if err != nil {
+		t.Fatalf("automationSpec() returned error: %s", err)
+	}
+	if spec.GetType() != task.TriggerAutomationSpecType_TYPE_NONE {
+		t.Errorf("Expected no schedule, got %s", spec.GetType())
+	}
+
+	if _, err := r.automationSpec(&TriggerResourceModel{
+		FixedRate: &TriggerFixedRateModel{
+			Value: types.Int64Value(1),
+			Unit:  types.StringValue("FIXED_RATE_UNIT_WEEK"),
+		},
+	}); err == nil {
+		t.Error("Expected automationSpec() to reject an unknown rate unit")
+	}
+}
+
+func TestTriggerResource_FixedRateRoundTrip(t *testing.T) {
+	r := &TriggerResource{org: "test-org"}
+	config := &TriggerFixedRateModel{
+		Value:     types.Int64Value(2),
+		Unit:      types.StringValue("FIXED_RATE_UNIT_HOUR"),
+		StartTime: types.StringValue("2025-01-01T09:30:00+02:00"),
+	}
+	spec, err := r.automationSpec(&TriggerResourceModel{FixedRate: config})
+	if err != nil {
+		t.Fatalf("automationSpec() returned error: %s", err)
+	}
+
+	prior := *config
+	data := &TriggerResourceModel{FixedRate: &prior}
+	r.refresh(data, &trigger.TriggerDetails{AutomationSpec: spec})
+	if *data.FixedRate != *config {
+		t.Errorf("Expected the fixed rate to read back as configured, got %+v", data.FixedRate)
+	}
+
+	// A start time changed remotely is read back as it is stored.
+	spec.GetSchedule().GetRate().StartTime = timestamppb.New(time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC))
+	r.refresh(data, &trigger.TriggerDetails{AutomationSpec: spec})
+	if got := data.FixedRate.StartTime.ValueString(); got != "2025-02-01T00:00:00Z" {
+		t.Errorf("Expected the remote start time, got %s", got)
+	}
+}
+
+func TestTriggerResource_DefaultStartTime(t *testing.T) {
+	r := &TriggerResource{org: "test-org"}
+	data := &TriggerResourceModel{
+		Project: types.StringValue("p"),
+		Domain:  types.StringValue("development"),
+		Name:    types.StringValue("t"),
+		FixedRate: &TriggerFixedRateModel{
+			Value:     types.Int64Value(1),
+			Unit:      types.StringValue("FIXED_RATE_UNIT_DAY"),
+			StartTime: types.StringUnknown(),
+		},
+	}
+	spec, err := r.automationSpec(data)
+	if err != nil {
+		t.Fatalf("automationSpec() returned error: %s", err)
+	}
+	if spec.GetSchedule().GetRate().GetStartTime() != nil {
+		t.Errorf("Expected no start time for an unknown start_time, got %s", spec.GetSchedule().GetRate().GetStartTime().AsTime())
+	}
+
+	// The server fills in the deploy time, which is recorded in state.
+	spec.GetSchedule().GetRate().StartTime = timestamppb.New(time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC))
+	r.refreshComputed(data, &trigger.TriggerDetails{AutomationSpec: spec})
+	if got := data.FixedRate.StartTime.ValueString(); got != "2025-03-01T12:00:00Z" {
+		t.Errorf("Expected the server start time, got %q", got)
+	}
+}
+
+func TestTriggerConfigEqual(t *testing.T) {
+	base := TriggerResourceModel{
+		Active:      types.BoolValue(true),
+		Cron:        &TriggerCronModel{Expression: types.StringValue("0 * * * *"), Timezone: types.StringNull()},
+		Labels:      types.MapNull(types.StringType),
+		Annotations: types.MapNull(types.StringType),
+		Envs:        types.MapNull(types.StringType),
+	}
+
+	same := base
+	same.Cron = &TriggerCronModel{Expression: types.StringValue("0 * * * *"), Timezone: types.StringNull()}
+	if !triggerConfigEqual(&base, &same) {
+		t.Error("Expected identical configurations to be equal")
+	}
+
+	paused := base
+	paused.Active = types.BoolValue(false)
+	if triggerConfigEqual(&base, &paused) {
+		t.Error("Expected a change of active to be detected")
+	}
+
+	rescheduled := base
+	rescheduled.Cron = &TriggerCronModel{Expression: types.StringValue("30 * * * *"), Timezone: types.StringNull()}
+	if triggerConfigEqual(&base, &rescheduled) {
+		t.Error("Expected a change of the cron expression to be detected")
+	}
+}
@@ -0,0 +1,387 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	flytecommon "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/common"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/trigger"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// triggerRevisionsPageSize is the page size used to walk the revision history.
+const triggerRevisionsPageSize = 100
+
+// Ensure provider defined types fully satisfy framework interfaces.
+var _ datasource.DataSource = &TriggerRevisionsDataSource{}
+
+func NewTriggerRevisionsDataSource() datasource.DataSource {
+	return &TriggerRevisionsDataSource{}
+}
+
+// TriggerRevisionsDataSource defines the data source implementation.
+type TriggerRevisionsDataSource struct {
+	conn trigger.TriggerServiceClient
+	org  string
+}
+
+// TriggerRevisionsDataSourceModel describes the data source data model.
+type TriggerRevisionsDataSourceModel struct {
+	Id        types.String                     `tfsdk:"id"`
+	Name      types.String                     `tfsdk:"name"`
+	Project   types.String                     `tfsdk:"project"`
+	Domain    types.String                     `tfsdk:"domain"`
+	TaskName  types.String                     `tfsdk:"task_name"`
+	Limit     types.Int64                      `tfsdk:"limit"`
+	Revisions []TriggerRevisionDataSourceModel `tfsdk:"revisions"`
+}
+
+type TriggerRevisionDataSourceModel struct {
+	Revision  types.Int64                 `tfsdk:"revision"`
+	Action    types.String                `tfsdk:"action"`
+	CreatedAt types.String                `tfsdk:"created_at"`
+	Actor     TriggerActorDataSourceModel `tfsdk:"actor"`
+	Spec      TriggerSpecDataSourceModel  `tfsdk:"spec"`
+}
+
+type TriggerActorDataSourceModel struct {
+	Type    types.String `tfsdk:"type"`
+	Subject types.String `tfsdk:"subject"`
+	Name    types.String `tfsdk:"name"`
+}
+
+type TriggerSpecDataSourceModel struct {
+	Active              types.Bool             `tfsdk:"active"`
+	TaskVersion         types.String           `tfsdk:"task_version"`
+	Description         types.String           `tfsdk:"description"`
+	KickoffTimeInputArg types.String           `tfsdk:"kickoff_time_input_arg"`
+	Cron                *TriggerCronModel      `tfsdk:"cron"`
+	FixedRate           *TriggerFixedRateModel `tfsdk:"fixed_rate"`
+	Labels              types.Map              `tfsdk:"labels"`
+	Annotations         types.Map              `tfsdk:"annotations"`
+	Envs                types.Map              `tfsdk:"envs"`
+	Interruptible       types.Bool             `tfsdk:"interruptible"`
+	OverwriteCache      types.Bool             `tfsdk:"overwrite_cache"`
+	Cluster             types.String           `tfsdk:"cluster"`
+}
+
+func (d *TriggerRevisionsDataSource) Metadata(ctx context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_trigger_revisions"
+}
+
+func (d *TriggerRevisionsDataSource) Schema(ctx context.Context, req datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		MarkdownDescription: "Trigger revisions data source. Lists the revision history of a trigger.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Trigger identifier, in the form `{project}/{domain}/{task_name}/{name}`",
+				Computed:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Trigger name",
+				Required:            true,
+			},
+			"project": schema.StringAttribute{
+				MarkdownDescription: "Project of the triggered task",
+				Required:            true,
+			},
+			"domain": schema.StringAttribute{
+				MarkdownDescription: "Domain of the triggered task",
+				Required:            true,
+			},
+			"task_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the triggered task",
+				Required:            true,
+			},
+			"limit": schema.Int64Attribute{
+				MarkdownDescription: "Maximum number of revisions to return. If unset, the whole history is returned.",
+				Optional:            true,
+			},
+			"revisions": schema.ListNestedAttribute{
+				MarkdownDescription: "Trigger revisions",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"revision": schema.Int64Attribute{
+							MarkdownDescription: "Revision number",
+							Computed:            true,
+						},
+						"action": schema.StringAttribute{
+							MarkdownDescription: "Change that created the revision (e.g. `TRIGGER_REVISION_ACTION_DEPLOY`, `TRIGGER_REVISION_ACTION_DEACTIVATE`)",
+							Computed:            true,
+						},
+						"created_at": schema.StringAttribute{
+							MarkdownDescription: "Time the revision was created, in RFC 3339 format",
+							Computed:            true,
+						},
+						"actor": schema.SingleNestedAttribute{
+							MarkdownDescription: "Identity that made the change",
+							Computed:            true,
+							Attributes: map[string]schema.Attribute{
+								"type": schema.StringAttribute{
+									MarkdownDescription: "Identity type, either `user` or `application`",
+									Computed:            true,
+								},
+								"subject": schema.StringAttribute{
+									MarkdownDescription: "Identity subject",
+									Computed:            true,
+								},
+								"name": schema.StringAttribute{
+									MarkdownDescription: "User email or application name",
+									Computed:            true,
+								},
+							},
+						},
+						"spec": schema.SingleNestedAttribute{
+							MarkdownDescription: "Trigger configuration at this revision",
+							Computed:            true,
+							Attributes:          triggerSpecDataSourceAttributes(),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func triggerSpecDataSourceAttributes() map[string]schema.Attribute {
+	return map[string]schema.Attribute{
+		"active": schema.BoolAttribute{
+			MarkdownDescription: "Whether the trigger launches runs",
+			Computed:            true,
+		},
+		"task_version": schema.StringAttribute{
+			MarkdownDescription: "Task version run by the trigger",
+			Computed:            true,
+		},
+		"description": schema.StringAttribute{
+			MarkdownDescription: "Trigger description",
+			Computed:            true,
+		},
+		"kickoff_time_input_arg": schema.StringAttribute{
+			MarkdownDescription: "Name of the task input that receives the scheduled kickoff time",
+			Computed:            true,
+		},
+		"cron": schema.SingleNestedAttribute{
+			MarkdownDescription: "Cron schedule",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"expression": schema.StringAttribute{
+					MarkdownDescription: "Cron expression",
+					Computed:            true,
+				},
+				"timezone": schema.StringAttribute{
+					MarkdownDescription: "Timezone the expression is evaluated in",
+					Computed:            true,
+				},
+			},
+		},
+		"fixed_rate": schema.SingleNestedAttribute{
+			MarkdownDescription: "Fixed-rate schedule",
+			Computed:            true,
+			Attributes: map[string]schema.Attribute{
+				"value": schema.Int64Attribute{
+					MarkdownDescription: "Number of units between runs",
+					Computed:            true,
+				},
+				"unit": schema.StringAttribute{
+					MarkdownDescription: "Rate unit",
+					Computed:            true,
+				},
+				"start_time": schema.StringAttribute{
+					MarkdownDescription: "Time of the first run, in RFC 3339 format",
+					Computed:            true,
+				},
+			},
+		},
+		"labels": schema.MapAttribute{
+			MarkdownDescription: "Labels applied to the triggered runs",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"annotations": schema.MapAttribute{
+			MarkdownDescription: "Annotations applied to the triggered runs",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"envs": schema.MapAttribute{
+			MarkdownDescription: "Environment variables set on the triggered runs",
+			Computed:            true,
+			ElementType:         types.StringType,
+		},
+		"interruptible": schema.BoolAttribute{
+			MarkdownDescription: "Interruptible override of the triggered runs",
+			Computed:            true,
+		},
+		"overwrite_cache": schema.BoolAttribute{
+			MarkdownDescription: "Whether the triggered runs overwrite cached outputs",
+			Computed:            true,
+		},
+		"cluster": schema.StringAttribute{
+			MarkdownDescription: "Cluster the triggered runs are sent to",
+			Computed:            true,
+		},
+	}
+}
+
+func (d *TriggerRevisionsDataSource) Configure(ctx context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Prevent panic if the provider has not been configured.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*providerContext)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *providerContext, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.conn = trigger.NewTriggerServiceClient(client.conn)
+	if d.conn == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *trigger.TriggerServiceClient, got: %T. Please report this issue to the provider developers.", d.conn),
+		)
+		return
+	}
+	d.org = client.org
+}
+
+func (d *TriggerRevisionsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var data TriggerRevisionsDataSourceModel
+
+	// Read Terraform configuration data into the model
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := &flytecommon.TriggerName{
+		Org:      d.org,
+		Project:  data.Project.ValueString(),
+		Domain:   data.Domain.ValueString(),
+		TaskName: data.TaskName.ValueString(),
+		Name:     data.Name.ValueString(),
+	}
+	data.Id = types.StringValue(triggerId(name))
+
+	limit := int(data.Limit.ValueInt64())
+	var history []*trigger.TriggerRevision
+	token := ""
+	for {
+		got, err := d.conn.GetTriggerRevisionHistory(ctx, &trigger.GetTriggerRevisionHistoryRequest{
+			Request: &flytecommon.ListRequest{
+				Limit: triggerRevisionsPageSize,
+				Token: token,
+			},
+			Name: name,
+		})
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				resp.Diagnostics.AddError("Trigger not found", fmt.Sprintf("Trigger with ID %s not found", data.Id.ValueString()))
+				return
+			}
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to list trigger revisions, got error: %s", err))
+			return
+		}
+		history = append(history, got.GetTriggers()...)
+		token = got.GetToken()
+		if token == "" || (limit > 0 && len(history) >= limit) {
+			break
+		}
+	}
+	if limit > 0 && len(history) > limit {
+		history = history[:limit]
+	}
+
+	data.Revisions = make([]TriggerRevisionDataSourceModel, 0, len(history))
+	for _, revision := range history {
+		details, err := d.conn.GetTriggerRevisionDetails(ctx, &trigger.GetTriggerRevisionDetailsRequest{
+			Id: revision.GetId(),
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Client Error", fmt.Sprintf("Unable to read trigger revision %d, got error: %s", revision.GetId().GetRevision(), err))
+			return
+		}
+
+		data.Revisions = append(data.Revisions, TriggerRevisionDataSourceModel{
+			Revision:  types.Int64Value(int64(revision.GetId().GetRevision())),
+			Action:    types.StringValue(revision.GetAction().String()),
+			CreatedAt: convertTimestampToString(revision.GetCreatedAt()),
+			Actor:     triggerRevisionActor(revision),
+			Spec:      triggerSpecDataSourceModel(details.GetTrigger()),
+		})
+	}
+
+	// Save data into Terraform state
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+// triggerRevisionActor returns the identity behind a revision: the deployer
+// for deploys, and the updater for activations and deactivations.
+func triggerRevisionActor(revision *trigger.TriggerRevision) TriggerActorDataSourceModel {
+	identity := revision.GetMetadata().GetDeployedBy()
+	switch revision.GetAction() {
+	case trigger.TriggerRevisionAction_TRIGGER_REVISION_ACTION_ACTIVATE,
+		trigger.TriggerRevisionAction_TRIGGER_REVISION_ACTION_DEACTIVATE,
+		trigger.TriggerRevisionAction_TRIGGER_REVISION_ACTION_DELETE:
+		if revision.GetMetadata().GetUpdatedBy() != nil {
+			identity = revision.GetMetadata().GetUpdatedBy()
+		}
+	}
+
+	switch {
+	case identity.GetUser() != nil:
+		return TriggerActorDataSourceModel{
+			Type:    types.StringValue("user"),
+			Subject: types.StringValue(identity.GetUser().GetId().GetSubject()),
+			Name:    optionalString(identity.GetUser().GetSpec().GetEmail()),
+		}
+	case identity.GetApplication() != nil:
+		return TriggerActorDataSourceModel{
+			Type:    types.StringValue("application"),
+			Subject: types.StringValue(identity.GetApplication().GetId().GetSubject()),
+			Name:    optionalString(identity.GetApplication().GetSpec().GetName()),
+		}
+	default:
+		return TriggerActorDataSourceModel{
+			Type:    types.StringNull(),
+			Subject: types.StringNull(),
+			Name:    types.StringNull(),
+		}
+	}
+}
+
+// triggerSpecDataSourceModel flattens a trigger revision into its
+// configuration, reusing the resource's state mapping.
+func triggerSpecDataSourceModel(details *trigger.TriggerDetails) TriggerSpecDataSourceModel {
+	var m TriggerResourceModel
+	(&TriggerResource{}).refresh(&m, details)
+
+	return TriggerSpecDataSourceModel{
+		Active:              m.Active,
+		TaskVersion:         m.TaskVersion,
+		Description:         m.Description,
+		KickoffTimeInputArg: m.KickoffTimeInputArg,
+		Cron:                m.Cron,
+		FixedRate:           m.FixedRate,
+		Labels:              m.Labels,
+		Annotations:         m.Annotations,
+		Envs:                m.Envs,
+		Interruptible:       m.Interruptible,
+		OverwriteCache:      types.BoolValue(details.GetSpec().GetRunSpec().GetOverwriteCache()),
+		Cluster:             m.Cluster,
+	}
+}
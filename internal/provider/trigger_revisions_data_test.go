@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"testing"
+
+	flytecommon "github.com/flyteorg/flyte/v2/gen/go/flyteidl2/common"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/task"
+	"github.com/flyteorg/flyte/v2/gen/go/flyteidl2/trigger"
+)
+
+func TestTriggerRevisionActor(t *testing.T) {
+	deployer := &flytecommon.EnrichedIdentity{
+		Principal: &flytecommon.EnrichedIdentity_User{
+			User: &flytecommon.User{
+				Id:   &flytecommon.UserIdentifier{Subject: "user-1"},
+				Spec: &flytecommon.UserSpec{Email: "jane@example.com"},
+			},
+		},
+	}
+	updater := &flytecommon.EnrichedIdentity{
+		Principal: &flytecommon.EnrichedIdentity_Application{
+			Application: &flytecommon.Application{
+				Id:   &flytecommon.ApplicationIdentifier{Subject: "app-1"},
+				Spec: &flytecommon.AppSpec{Name: "ci"},
+			},
+		},
+	}
+	metadata := &trigger.TriggerMetadata{DeployedBy: deployer, UpdatedBy: updater}
+
+	actor := triggerRevisionActor(&trigger.TriggerRevision{
+		Action:   trigger.TriggerRevisionAction_TRIGGER_REVISION_ACTION_DEPLOY,
+		Metadata: metadata,
+	})
+	if actor.Type.ValueString() != "user" || actor.Name.ValueString() != "jane@example.com" {
+		t.Errorf("Expected the deployer for a deploy, got %s %s", actor.Type, actor.Name)
+	}
+
+	actor = triggerRevisionActor(&trigger.TriggerRevision{
+		Action:   trigger.TriggerRevisionAction_TRIGGER_REVISION_ACTION_DEACTIVATE,
+		Metadata: metadata,
+	})
+	if actor.Type.ValueString() != "application" || actor.Subject.ValueString() != "app-1" {
+		t.Errorf("Expected the updater for a deactivation, got %s %s", actor.Type, actor.Subject)
+	}
+
+	actor = triggerRevisionActor(&trigger.TriggerRevision{})
+	if !actor.Type.IsNull() {
+		t.Errorf("Expected a null actor without metadata, got %s", actor.Type)
+	}
+}
+
+func TestTriggerSpecDataSourceModel(t *testing.T) {
+	spec := triggerSpecDataSourceModel(&trigger.TriggerDetails{
+		Id: &flytecommon.TriggerIdentifier{
+			Name:     &flytecommon.TriggerName{Project: "p", Domain: "d", TaskName: "t", Name: "n"},
+			Revision: 3,
+		},
+		Spec: &trigger.TriggerSpec{
+			Active: true,
+			RunSpec: &task.RunSpec{
+				Labels: &task.Labels{Values: map[string]string{"team": "ml"}},
+			},
+		},
+		AutomationSpec: &task.TriggerAutomationSpec{
+			Type: task.TriggerAutomationSpecType_TYPE_SCHEDULE,
+			Automation: &task.TriggerAutomationSpec_Schedule{
+				Schedule: &task.Schedule{
+					Expression: &task.Schedule_Cron{Cron: &task.Cron{Expression: "0 2 * * *"}},
+				},
+			},
+		},
+	})
+
+	if !spec.Active.ValueBool() {
+		t.Error("Expected an active trigger")
+	}
+	if spec.Cron == nil || spec.Cron.Expression.ValueString() != "0 2 * * *" {
+		t.Errorf("Expected cron expression '0 2 * * *', got %v", spec.Cron)
+	}
+	if spec.FixedRate != nil {
+		t.Errorf("Expected no fixed rate, got %v", spec.FixedRate)
+	}
+	if got := convertMapToStrings(spec.Labels)["team"]; got != "ml" {
+		t.Errorf("Expected label team=ml, got '%s'", got)
+	}
+	if spec.OverwriteCache.IsNull() || spec.OverwriteCache.ValueBool() {
+		t.Errorf("Expected overwrite_cache to be false, got %s", spec.OverwriteCache)
+	}
+}
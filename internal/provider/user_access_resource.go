@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
@@ -12,6 +13,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/unionai/cloud/gen/pb-go/authorizer"
 	"github.com/unionai/cloud/gen/pb-go/common"
+	"github.com/unionai/cloud/gen/pb-go/identity"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 )
@@ -26,8 +28,9 @@ func NewUserAccessResource() resource.Resource {
 
 // UserAccessResource defines the resource implementation.
 type UserAccessResource struct {
-	conn authorizer.AuthorizerServiceClient
-	org  string
+	conn  authorizer.AuthorizerServiceClient
+	users identity.UserServiceClient
+	org   string
 }
 
 // UserAccessResourceModel describes the resource data model.
@@ -88,6 +91,14 @@ func (r *UserAccessResource) Configure(ctx context.Context, req resource.Configu
 		)
 		return
 	}
+	r.users = identity.NewUserServiceClient(client.conn)
+	if r.users == nil {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *identity.UserServiceClient, got: %T. Please report this issue to the provider developers.", r.users),
+		)
+		return
+	}
 	r.org = client.org
 }
 
@@ -139,7 +150,7 @@ func (r *UserAccessResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
-	_, err := r.conn.GetIdentityAssignments(ctx, &authorizer.GetIdentityAssignmentRequest{
+	result, err := r.conn.GetIdentityAssignments(ctx, &authorizer.GetIdentityAssignmentRequest{
 		Organization: r.org,
 		Identity: &common.Identity{
 			Principal: &common.Identity_UserId{
@@ -162,6 +173,19 @@ func (r *UserAccessResource) Read(ctx context.Context, req resource.ReadRequest,
 		return
 	}
 
+	// Verify the specific policy assignment still exists
+	var assigned bool
+	for _, p := range result.GetIdentityAssignment().GetPolicies() {
+		if p.GetId().GetName() == data.Policy.ValueString() {
+			assigned = true
+			break
+		}
+	}
+	if !assigned {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	// Save updated data into Terraform state
 	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
 }
@@ -216,6 +240,44 @@ func (r *UserAccessResource) Delete(ctx context.Context, req resource.DeleteRequ
 	}
 }
 
+// ImportState accepts `user_subject/policy` or `email/policy`. Emails are
+// resolved to the subject of the user.
 func (r *UserAccessResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	idx := strings.LastIndex(req.ID, "/")
+	if idx <= 0 || idx == len(req.ID)-1 {
+		resp.Diagnostics.AddError(
+			"Unexpected Import Identifier",
+			fmt.Sprintf("Expected import identifier with format: user_subject/policy or email/policy. Got: %q", req.ID),
+		)
+		return
+	}
+	user, policy := req.ID[:idx], req.ID[idx+1:]
+
+	if strings.Contains(user, "@") {
+		users, err := r.users.ListUsers(ctx, &identity.ListUsersRequest{
+			Organization: r.org,
+			Request: &common.ListRequest{
+				Filters: []*common.Filter{
+					{
+						Field:    "email",
+						Function: common.Filter_EQUAL,
+						Values:   []string{user},
+					},
+				},
+			},
+			IncludeSupportStaff: true,
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Failed to fetch user", err.Error())
+			return
+		}
+		if len(users.Users) == 0 {
+			resp.Diagnostics.AddError("User not found", fmt.Sprintf("User %s not found", user))
+			return
+		}
+		user = users.Users[0].GetId().GetSubject()
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("user"), user)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("policy"), policy)...)
 }
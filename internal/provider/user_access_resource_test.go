@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/unionai/cloud/gen/pb-go/authorizer"
+	"github.com/unionai/cloud/gen/pb-go/common"
+	"github.com/unionai/cloud/gen/pb-go/identity"
+	"google.golang.org/grpc"
+)
+
+// mockUserClient implements the subset of identity.UserServiceClient used to
+// look users up by email.
+type mockUserClient struct {
+	identity.UserServiceClient
+	users []*common.User
+}
+
+func (m *mockUserClient) ListUsers(ctx context.Context, in *identity.ListUsersRequest, opts ...grpc.CallOption) (*identity.ListUsersResponse, error) {
+	var out []*common.User
+	for _, u := range m.users {
+		if u.GetSpec().GetEmail() == in.GetRequest().GetFilters()[0].GetValues()[0] {
+			out = append(out, u)
+		}
+	}
+	return &identity.ListUsersResponse{Users: out}, nil
+}
+
+func userAccessState(t *testing.T, user, policy string) tfsdk.State {
+	t.Helper()
+	r := &UserAccessResource{}
+	schemaResp := &resource.SchemaResponse{}
+	r.Schema(context.Background(), resource.SchemaRequest{}, schemaResp)
+
+	objectType := tftypes.Object{AttributeTypes: map[string]tftypes.Type{"user": tftypes.String, "policy": tftypes.String}}
+	if user == "" {
+		return tfsdk.State{Schema: schemaResp.Schema, Raw: tftypes.NewValue(objectType, nil)}
+	}
+	return tfsdk.State{
+		Schema: schemaResp.Schema,
+		Raw: tftypes.NewValue(objectType, map[string]tftypes.Value{
+			"user":   tftypes.NewValue(tftypes.String, user),
+			"policy": tftypes.NewValue(tftypes.String, policy),
+		}),
+	}
+}
+
+func TestUserAccessResource_Read_PolicyNoLongerAssigned(t *testing.T) {
+	policies := []*common.Policy{{Id: &common.PolicyIdentifier{Name: "my-policy", Organization: "test-org"}}}
+	mock := &mockAuthorizerClient{
+		getAssignFn: func(ctx context.Context, req *authorizer.GetIdentityAssignmentRequest) (*authorizer.GetIdentityAssignmentResponse, error) {
+			return &authorizer.GetIdentityAssignmentResponse{
+				IdentityAssignment: &authorizer.IdentityAssignment{Policies: policies},
+			}, nil
+		},
+	}
+	r := &UserAccessResource{conn: mock, org: "test-org"}
+
+	state := userAccessState(t, "user-1", "my-policy")
+	resp := &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		t.Fatalf("Expected the assigned policy to be kept, got %v", resp.Diagnostics.Errors())
+	}
+
+	// Assignments do not always carry the organization of the policy
+	policies = []*common.Policy{{Id: &common.PolicyIdentifier{Name: "my-policy"}}}
+	resp = &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() || resp.State.Raw.IsNull() {
+		t.Fatalf("Expected a policy without organization to be kept, got %v", resp.Diagnostics.Errors())
+	}
+
+	policies = []*common.Policy{{Id: &common.PolicyIdentifier{Name: "other-policy", Organization: "test-org"}}}
+	resp = &resource.ReadResponse{State: state}
+	r.Read(context.Background(), resource.ReadRequest{State: state}, resp)
+	if resp.Diagnostics.HasError() || !resp.State.Raw.IsNull() {
+		t.Error("Expected state to be removed when the policy is no longer assigned")
+	}
+}
+
+func TestUserAccessResource_ImportState(t *testing.T) {
+	r := &UserAccessResource{
+		org:   "test-org",
+		users: &mockUserClient{users: []*common.User{{Id: &common.UserIdentifier{Subject: "user-1"}, Spec: &common.UserSpec{Email: "jane@example.com"}}}},
+	}
+
+	for id, expected := range map[string]string{"user-1/my-policy": "user-1", "jane@example.com/my-policy": "user-1"} {
+		resp := &resource.ImportStateResponse{State: userAccessState(t, "", "")}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if resp.Diagnostics.HasError() {
+			t.Fatalf("ImportState(%q) errors: %v", id, resp.Diagnostics.Errors())
+		}
+		var data UserAccessResourceModel
+		resp.State.Get(context.Background(), &data)
+		if data.User.ValueString() != expected || data.Policy.ValueString() != "my-policy" {
+			t.Errorf("ImportState(%q) = %+v", id, data)
+		}
+	}
+
+	for _, id := range []string{"my-policy", "user-1/", "nobody@example.com/my-policy"} {
+		resp := &resource.ImportStateResponse{State: userAccessState(t, "", "")}
+		r.ImportState(context.Background(), resource.ImportStateRequest{ID: id}, resp)
+		if !resp.Diagnostics.HasError() {
+			t.Errorf("Expected ImportState(%q) to fail", id)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// stringOneOfValidator checks that a string attribute is exactly one of a set
+// of values. Values are compared case-sensitively, so the configured value is
+// the one read back into state.
+type stringOneOfValidator struct {
+	values []string
+}
+
+// stringOneOf returns a validator accepting only the given values.
+func stringOneOf(values ...string) validator.String {
+	return stringOneOfValidator{values: values}
+}
+
+func (v stringOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("value must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v stringOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v stringOneOfValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	if slices.Contains(v.values, req.ConfigValue.ValueString()) {
+		return
+	}
+	resp.Diagnostics.AddAttributeError(
+		req.Path,
+		"Invalid Attribute Value",
+		fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), req.ConfigValue.ValueString()),
+	)
+}
+
+// mapKeysOneOfValidator checks that every key of a map attribute is exactly
+// one of a set of values.
+type mapKeysOneOfValidator struct {
+	values []string
+}
+
+// mapKeysOneOf returns a validator accepting only maps keyed by the given
+// values.
+func mapKeysOneOf(values ...string) validator.Map {
+	return mapKeysOneOfValidator{values: values}
+}
+
+func (v mapKeysOneOfValidator) Description(ctx context.Context) string {
+	return fmt.Sprintf("keys must be one of: %s", strings.Join(v.values, ", "))
+}
+
+func (v mapKeysOneOfValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v mapKeysOneOfValidator) ValidateMap(ctx context.Context, req validator.MapRequest, resp *validator.MapResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	for key := range req.ConfigValue.Elements() {
+		if slices.Contains(v.values, key) {
+			continue
+		}
+		resp.Diagnostics.AddAttributeError(
+			req.Path.AtMapKey(key),
+			"Invalid Attribute Value",
+			fmt.Sprintf("Attribute %s %s, got: %q", req.Path, v.Description(ctx), key),
+		)
+	}
+}
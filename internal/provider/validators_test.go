@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestStringOneOf(t *testing.T) {
+	v := stringOneOf("aws", "gcp")
+	for value, valid := range map[types.String]bool{
+		types.StringValue("aws"): true,
+		types.StringValue("AWS"): false,
+		types.StringValue("ibm"): false,
+		types.StringNull():       true,
+		types.StringUnknown():    true,
+	} {
+		resp := &validator.StringResponse{}
+		v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("cloud"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() == valid {
+			t.Errorf("ValidateString(%s) errors = %v, want valid %v", value, resp.Diagnostics.Errors(), valid)
+		}
+	}
+}
+
+func TestMapKeysOneOf(t *testing.T) {
+	v := mapKeysOneOf("cpu", "gpu")
+	for name, value := range map[string]types.Map{
+		"valid":   types.MapValueMust(types.StringType, map[string]attr.Value{"cpu": types.StringValue("4")}),
+		"invalid": types.MapValueMust(types.StringType, map[string]attr.Value{"CPU": types.StringValue("4")}),
+		"null":    types.MapNull(types.StringType),
+	} {
+		resp := &validator.MapResponse{}
+		v.ValidateMap(context.Background(), validator.MapRequest{Path: path.Root("allocatable"), ConfigValue: value}, resp)
+		if resp.Diagnostics.HasError() != (name == "invalid") {
+			t.Errorf("ValidateMap(%s) errors = %v", name, resp.Diagnostics.Errors())
+		}
+	}
+}